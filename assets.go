@@ -0,0 +1,200 @@
+package dovetail
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"golang.org/x/net/html/atom"
+)
+
+type asset struct {
+	fingerprintedPath string
+	integrity         string
+}
+
+// Assets references the files in a directory by fingerprinted URLs such as /assets/app.3f9a1c2b.css, with subresource integrity hashes.
+// The URLs change whenever the files do, so they can be cached forever.
+type Assets struct {
+	fs       http.FileSystem
+	prefix   string
+	byPath   map[string]asset
+	byPrint  map[string]string
+	fallback http.Handler
+}
+
+// LoadAssets hashes every file in fs once, such as at startup, to be served under the URL prefix like "/assets/".
+// Use http.Dir for a local directory.
+func LoadAssets(fs http.FileSystem, prefix string) (*Assets, error) {
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	assets := &Assets{
+		fs:       fs,
+		prefix:   prefix,
+		byPath:   make(map[string]asset),
+		byPrint:  make(map[string]string),
+		fallback: http.StripPrefix(strings.TrimSuffix(prefix, "/"), http.FileServer(noListingFileSystem{fs})),
+	}
+	if err := assets.load("/"); err != nil {
+		return nil, err
+	}
+	return assets, nil
+}
+
+func (assets *Assets) load(dir string) error {
+	f, err := assets.fs.Open(dir)
+	if err != nil {
+		return err
+	}
+	infos, err := f.Readdir(-1)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		filePath := path.Join(dir, info.Name())
+		if info.IsDir() {
+			if err := assets.load(filePath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		sum, err := assets.hash(filePath)
+		if err != nil {
+			return err
+		}
+		loaded := asset{
+			fingerprintedPath: fingerprintPath(filePath, hex.EncodeToString(sum)[:8]),
+			integrity:         "sha384-" + base64.StdEncoding.EncodeToString(sum),
+		}
+		assets.byPath[filePath] = loaded
+		assets.byPrint[loaded.fingerprintedPath] = filePath
+	}
+	return nil
+}
+
+func (assets *Assets) hash(filePath string) ([]byte, error) {
+	f, err := assets.fs.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha512.New384()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// noListingFileSystem hides directories without an index.html, so http.FileServer responds with not found instead of listing their files
+type noListingFileSystem struct {
+	fs http.FileSystem
+}
+
+func (fs noListingFileSystem) Open(name string) (http.File, error) {
+	f, err := fs.fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if info.IsDir() {
+		index, err := fs.fs.Open(path.Join(name, "index.html"))
+		if err != nil {
+			f.Close()
+			return nil, os.ErrNotExist
+		}
+		index.Close()
+	}
+	return f, nil
+}
+
+// fingerprintPath adds the fingerprint before the extension, so /app.css becomes /app.3f9a1c2b.css
+func fingerprintPath(filePath string, fingerprint string) string {
+	ext := path.Ext(filePath)
+	return strings.TrimSuffix(filePath, ext) + "." + fingerprint + ext
+}
+
+func cleanAssetPath(filePath string) string {
+	return path.Clean("/" + filePath)
+}
+
+// URL returns the fingerprinted URL for the file at filePath within the directory, such as "app.css".
+// Files that were not loaded are referenced without a fingerprint.
+func (assets *Assets) URL(filePath string) string {
+	filePath = cleanAssetPath(filePath)
+	if loaded, ok := assets.byPath[filePath]; ok {
+		filePath = loaded.fingerprintedPath
+	}
+	return assets.prefix + strings.TrimPrefix(filePath, "/")
+}
+
+// Integrity returns the subresource integrity hash for the file at filePath, or "" if it was not loaded
+func (assets *Assets) Integrity(filePath string) string {
+	return assets.byPath[cleanAssetPath(filePath)].integrity
+}
+
+func (assets *Assets) integrityAttrs(filePath string) []HTMLView {
+	integrity := assets.Integrity(filePath)
+	if integrity == "" {
+		return nil
+	}
+	return []HTMLView{CustomAttr("integrity", integrity), CustomAttr("crossorigin", "anonymous")}
+}
+
+// Script makes <script src="…" integrity="…"> for the file at filePath
+func (assets *Assets) Script(filePath string, enhancers ...HTMLEnhancer) HTMLElementView {
+	children := append([]HTMLView{CustomAttr("src", assets.URL(filePath))}, assets.integrityAttrs(filePath)...)
+	return HTMLElementViewOf("script", atom.Script, children).Use(enhancers...)
+}
+
+// Stylesheet makes <link rel="stylesheet" href="…" integrity="…"> for the file at filePath, for use within Head
+func (assets *Assets) Stylesheet(filePath string, enhancers ...HTMLEnhancer) HTMLElementView {
+	children := append([]HTMLView{CustomAttr("rel", "stylesheet"), CustomAttr("href", assets.URL(filePath))}, assets.integrityAttrs(filePath)...)
+	return HTMLElementViewOf("link", atom.Link, children).Use(enhancers...)
+}
+
+// ServeHTTP serves fingerprinted URLs with immutable caching, and any other file under the prefix like http.FileServer.
+// Directories are served by their index.html, and are otherwise not found rather than listed.
+func (assets *Assets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, assets.prefix) {
+		http.NotFound(w, r)
+		return
+	}
+
+	fingerprintedPath := "/" + strings.TrimPrefix(r.URL.Path, assets.prefix)
+	filePath, ok := assets.byPrint[fingerprintedPath]
+	if !ok {
+		// The file may have changed since it was loaded, so caches must check again
+		w.Header().Set("Cache-Control", "no-cache")
+		assets.fallback.ServeHTTP(w, r)
+		return
+	}
+
+	f, err := assets.fs.Open(filePath)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	http.ServeContent(w, r, filePath, info.ModTime(), f)
+}
@@ -0,0 +1,92 @@
+package dovetail
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestAssets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dovetail-assets")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "js"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "app.css"), []byte("body { margin: 0 }"), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "js", "app.js"), []byte("go()"), 0644))
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "docs"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "docs", "index.html"), []byte("<h1>Docs</h1>"), 0644))
+
+	assets, err := LoadAssets(http.Dir(dir), "/assets/")
+	assert.NilError(t, err)
+
+	t.Run("Referencing assets", func(t *testing.T) {
+		s := subjectAsString(Div(assets.Stylesheet("app.css"), assets.Script("/js/app.js"), assets.Script("missing.js")))
+
+		t.Run(`it renders fingerprinted URLs with integrity hashes`, func(t *testing.T) {
+			assert.Equal(t, s, `<div>`+
+				`<link rel="stylesheet" href="/assets/app.2ea44eb7.css" integrity="sha384-LqROt08EVeMth/KLSsFLNEYyDxdxkMNHUVSlR1OFXlOstklYqxMOwTAUvGyhIq1U" crossorigin="anonymous"/>`+
+				`<script src="/assets/js/app.d9e47679.js" integrity="sha384-2eR2ebRL3PTAzYG2tevlN+8WTrXsUaQaggr559th5ntotR7G/oN0/gI71qhJrQ5e" crossorigin="anonymous"></script>`+
+				`<script src="/assets/missing.js"></script>`+
+				`</div>`)
+		})
+	})
+
+	t.Run("Serving a fingerprinted URL", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		assets.ServeHTTP(w, httptest.NewRequest("GET", assets.URL("app.css"), nil))
+
+		t.Run(`it serves the file with immutable caching`, func(t *testing.T) {
+			assert.Equal(t, w.Code, 200)
+			assert.Equal(t, w.Body.String(), "body { margin: 0 }")
+			assert.Equal(t, w.Header().Get("Cache-Control"), "public, max-age=31536000, immutable")
+			assert.Equal(t, w.Header().Get("Content-Type"), "text/css; charset=utf-8")
+		})
+	})
+
+	t.Run("Serving a URL without a fingerprint", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		assets.ServeHTTP(w, httptest.NewRequest("GET", "/assets/js/app.js", nil))
+
+		t.Run(`it serves the file without caching`, func(t *testing.T) {
+			assert.Equal(t, w.Code, 200)
+			assert.Equal(t, w.Body.String(), "go()")
+			assert.Equal(t, w.Header().Get("Cache-Control"), "no-cache")
+		})
+	})
+
+	t.Run("Serving a directory", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		assets.ServeHTTP(w, httptest.NewRequest("GET", "/assets/js/", nil))
+
+		t.Run(`it responds with not found instead of listing the files`, func(t *testing.T) {
+			assert.Equal(t, w.Code, 404)
+			assert.Assert(t, !strings.Contains(w.Body.String(), "app.js"))
+		})
+	})
+
+	t.Run("Serving a directory with an index.html", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		assets.ServeHTTP(w, httptest.NewRequest("GET", "/assets/docs/", nil))
+
+		t.Run(`it serves the index`, func(t *testing.T) {
+			assert.Equal(t, w.Code, 200)
+			assert.Equal(t, w.Body.String(), "<h1>Docs</h1>")
+		})
+	})
+
+	t.Run("Serving a URL outside the prefix", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		assets.ServeHTTP(w, httptest.NewRequest("GET", "/app.css", nil))
+
+		t.Run(`it responds with not found`, func(t *testing.T) {
+			assert.Equal(t, w.Code, 404)
+		})
+	})
+}
@@ -0,0 +1,136 @@
+package dovetail
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InvalidAttrError is reported when rendering an attribute whose key is malformed or unsafe. The attribute is left out.
+type InvalidAttrError struct {
+	Key    string
+	Reason string
+}
+
+func (err InvalidAttrError) Error() string {
+	return fmt.Sprintf("dovetail: attribute %q %s", err.Key, err.Reason)
+}
+
+// renderErrors is every error reported while rendering
+type renderErrors []error
+
+func (errs renderErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ariaAttrKeys are the states and properties from WAI-ARIA 1.2
+var ariaAttrKeys = map[string]bool{
+	"aria-activedescendant":       true,
+	"aria-atomic":                 true,
+	"aria-autocomplete":           true,
+	"aria-braillelabel":           true,
+	"aria-brailleroledescription": true,
+	"aria-busy":                   true,
+	"aria-checked":                true,
+	"aria-colcount":               true,
+	"aria-colindex":               true,
+	"aria-colindextext":           true,
+	"aria-colspan":                true,
+	"aria-controls":               true,
+	"aria-current":                true,
+	"aria-describedby":            true,
+	"aria-description":            true,
+	"aria-details":                true,
+	"aria-disabled":               true,
+	"aria-dropeffect":             true,
+	"aria-errormessage":           true,
+	"aria-expanded":               true,
+	"aria-flowto":                 true,
+	"aria-grabbed":                true,
+	"aria-haspopup":               true,
+	"aria-hidden":                 true,
+	"aria-invalid":                true,
+	"aria-keyshortcuts":           true,
+	"aria-label":                  true,
+	"aria-labelledby":             true,
+	"aria-level":                  true,
+	"aria-live":                   true,
+	"aria-modal":                  true,
+	"aria-multiline":              true,
+	"aria-multiselectable":        true,
+	"aria-orientation":            true,
+	"aria-owns":                   true,
+	"aria-placeholder":            true,
+	"aria-posinset":               true,
+	"aria-pressed":                true,
+	"aria-readonly":               true,
+	"aria-relevant":               true,
+	"aria-required":               true,
+	"aria-roledescription":        true,
+	"aria-rowcount":               true,
+	"aria-rowindex":               true,
+	"aria-rowindextext":           true,
+	"aria-rowspan":                true,
+	"aria-selected":               true,
+	"aria-setsize":                true,
+	"aria-sort":                   true,
+	"aria-valuemax":               true,
+	"aria-valuemin":               true,
+	"aria-valuenow":               true,
+	"aria-valuetext":              true,
+}
+
+// isAttrNameChar reports whether r may be used in an attribute name, following the HTML spec
+func isAttrNameChar(r rune) bool {
+	switch {
+	case r <= 0x20, r >= 0x7f && r <= 0x9f:
+		// Controls and space
+		return false
+	case r == '"', r == '\'', r == '>', r == '/', r == '=':
+		return false
+	case r >= 0xfdd0 && r <= 0xfdef, r&0xfffe == 0xfffe:
+		// Noncharacters
+		return false
+	}
+	return true
+}
+
+// validateAttrKey returns an InvalidAttrError if the key would corrupt the markup or is not allowed
+func validateAttrKey(key string, eventHandler bool) error {
+	if key == "" {
+		return InvalidAttrError{Key: key, Reason: "is empty"}
+	}
+	for _, r := range key {
+		if !isAttrNameChar(r) {
+			return InvalidAttrError{Key: key, Reason: fmt.Sprintf("contains %q", r)}
+		}
+	}
+
+	lowerKey := strings.ToLower(key)
+	switch {
+	case strings.HasPrefix(lowerKey, "on"):
+		if !eventHandler {
+			return InvalidAttrError{Key: key, Reason: "is an event handler, use EventHandlerAttr to allow it"}
+		}
+	case strings.HasPrefix(lowerKey, "aria-"):
+		if !ariaAttrKeys[lowerKey] {
+			return InvalidAttrError{Key: key, Reason: "is not a WAI-ARIA attribute"}
+		}
+	case strings.HasPrefix(lowerKey, "data-"):
+		name := key[len("data-"):]
+		switch {
+		case name == "":
+			return InvalidAttrError{Key: key, Reason: "needs a name after data-"}
+		case strings.HasPrefix(lowerKey, "data-xml"):
+			return InvalidAttrError{Key: key, Reason: "must not start with data-xml"}
+		case strings.ContainsRune(name, ':'):
+			return InvalidAttrError{Key: key, Reason: "must not contain a colon"}
+		case strings.ToLower(key) != key:
+			return InvalidAttrError{Key: key, Reason: "must not contain uppercase letters"}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package dovetail
+
+import (
+	"bytes"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestAttrValidation(t *testing.T) {
+	t.Run("Rendering valid attributes", func(t *testing.T) {
+		b := new(bytes.Buffer)
+		err := Render(b, Div(
+			AriaAttr("describedby", "hint"),
+			DataAttr("test-id", "x"),
+			CustomAttr("hx-post", "/save"),
+			EventHandlerAttr("click", "go()"),
+		))
+
+		t.Run(`it renders them without an error`, func(t *testing.T) {
+			assert.NilError(t, err)
+			assert.Equal(t, b.String(), `<div aria-describedby="hint" data-test-id="x" hx-post="/save" onclick="go()"></div>`)
+		})
+	})
+
+	t.Run("Rendering invalid attributes", func(t *testing.T) {
+		b := new(bytes.Buffer)
+		err := Render(b, Div(
+			CustomAttr(`x" onload="alert(1)`, ""),
+			CustomAttr("onclick", "steal()"),
+			CustomAttr("OnMouseOver", "steal()"),
+			AriaAttr("labeledby", "title"),
+			DataAttr("testId", "x"),
+			DataAttr("xml-thing", "x"),
+			DataAttr("", "x"),
+			CustomAttr("title", "Kept"),
+		))
+
+		t.Run(`it leaves them out`, func(t *testing.T) {
+			assert.Equal(t, b.String(), `<div title="Kept"></div>`)
+		})
+
+		t.Run(`it returns an error for each`, func(t *testing.T) {
+			assert.Error(t, err, `dovetail: attribute "x\" onload=\"alert(1)" contains '"'; `+
+				`dovetail: attribute "onclick" is an event handler, use EventHandlerAttr to allow it; `+
+				`dovetail: attribute "OnMouseOver" is an event handler, use EventHandlerAttr to allow it; `+
+				`dovetail: attribute "aria-labeledby" is not a WAI-ARIA attribute; `+
+				`dovetail: attribute "data-testId" must not contain uppercase letters; `+
+				`dovetail: attribute "data-xml-thing" must not start with data-xml; `+
+				`dovetail: attribute "data-" needs a name after data-`)
+		})
+	})
+
+	t.Run("Rendering a WAI-ARIA attribute in mixed case", func(t *testing.T) {
+		b := new(bytes.Buffer)
+		err := Render(b, Button(CustomAttr("ARIA-Label", "Close")))
+
+		t.Run(`it matches case-insensitively like HTML`, func(t *testing.T) {
+			assert.NilError(t, err)
+			assert.Equal(t, b.String(), `<button ARIA-Label="Close" type="button"></button>`)
+		})
+	})
+
+	t.Run("Rendering one invalid attribute", func(t *testing.T) {
+		err := Render(new(bytes.Buffer), P(CustomAttr("a=b", "")))
+
+		t.Run(`it returns an InvalidAttrError`, func(t *testing.T) {
+			assert.DeepEqual(t, err, InvalidAttrError{Key: "a=b", Reason: `contains '='`})
+		})
+	})
+}
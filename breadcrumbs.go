@@ -0,0 +1,98 @@
+package dovetail
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"golang.org/x/net/html"
+)
+
+// Breadcrumb is a page within Breadcrumbs
+type Breadcrumb struct {
+	url  string
+	name string
+}
+
+// Crumb makes a breadcrumb linking to the page at url with the name
+func Crumb(url string, name string) Breadcrumb {
+	return Breadcrumb{url: url, name: name}
+}
+
+// BreadcrumbsView makes breadcrumb navigation, from the home page to the current page
+type BreadcrumbsView struct {
+	items       []Breadcrumb
+	baseURL     string
+	jsonLD      bool
+	elementCore HTMLElementCore
+}
+
+// Breadcrumbs makes <nav aria-label="Breadcrumb"> with an ordered list of links, where the last is the current page
+func Breadcrumbs(items ...Breadcrumb) BreadcrumbsView {
+	return BreadcrumbsView{items: items}
+}
+
+// StructuredData adds a schema.org BreadcrumbList to the <head> as JSON-LD, with relative URLs resolved against baseURL
+func (breadcrumbs BreadcrumbsView) StructuredData(baseURL string) BreadcrumbsView {
+	breadcrumbs.baseURL = baseURL
+	breadcrumbs.jsonLD = true
+	return breadcrumbs
+}
+
+// Use the provided enhancers
+func (breadcrumbs BreadcrumbsView) Use(enhancers ...HTMLEnhancer) BreadcrumbsView {
+	breadcrumbs.elementCore = breadcrumbs.elementCore.Use(enhancers...)
+	return breadcrumbs
+}
+
+func (breadcrumbs BreadcrumbsView) Class(classNames ...string) BreadcrumbsView {
+	breadcrumbs.elementCore.classNames = append(breadcrumbs.elementCore.classNames, classNames...)
+	return breadcrumbs
+}
+
+type jsonLDListItem struct {
+	Type     string `json:"@type"`
+	Position int    `json:"position"`
+	Name     string `json:"name"`
+	Item     string `json:"item"`
+}
+
+type jsonLDBreadcrumbList struct {
+	Context         string           `json:"@context"`
+	Type            string           `json:"@type"`
+	ItemListElement []jsonLDListItem `json:"itemListElement"`
+}
+
+// structuredData returns the BreadcrumbList as JSON, which escapes < and > so it can’t close its <script>
+func (breadcrumbs BreadcrumbsView) structuredData() string {
+	list := jsonLDBreadcrumbList{Context: "https://schema.org", Type: "BreadcrumbList", ItemListElement: []jsonLDListItem{}}
+	base, baseErr := url.Parse(breadcrumbs.baseURL)
+	for index, item := range breadcrumbs.items {
+		itemURL := item.url
+		if ref, err := url.Parse(item.url); err == nil && baseErr == nil {
+			itemURL = base.ResolveReference(ref).String()
+		}
+		list.ItemListElement = append(list.ItemListElement, jsonLDListItem{Type: "ListItem", Position: index + 1, Name: item.name, Item: itemURL})
+	}
+
+	data, _ := json.Marshal(list)
+	return string(data)
+}
+
+func (breadcrumbs BreadcrumbsView) apply(node *html.Node, ctx *buildContext) {
+	links := make([]HTMLView, 0, len(breadcrumbs.items))
+	for index, item := range breadcrumbs.items {
+		link := Link(item.url, Text(item.name))
+		if index == len(breadcrumbs.items)-1 {
+			link = link.Use(AriaCurrentPage)
+		}
+		links = append(links, link)
+	}
+
+	nav := Nav(AriaLabel("Breadcrumb"), OrderedList(links...))
+	nav.elementCore.children = append(nav.elementCore.children, breadcrumbs.elementCore.children...)
+	nav.elementCore.classNames = breadcrumbs.elementCore.classNames
+	if breadcrumbs.jsonLD {
+		nav = nav.Use(Head(InlineScript(breadcrumbs.structuredData(), CustomAttr("type", "application/ld+json"))))
+	}
+	nav.apply(node, ctx)
+}
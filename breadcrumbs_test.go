@@ -0,0 +1,43 @@
+package dovetail
+
+import (
+	"bytes"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestBreadcrumbs(t *testing.T) {
+	breadcrumbs := Breadcrumbs(
+		Crumb("/", "Home"),
+		Crumb("/docs", "Docs"),
+		Crumb("/docs/forms", "Forms & fields"),
+	)
+
+	t.Run("Rendering Breadcrumbs", func(t *testing.T) {
+		s := subjectAsString(breadcrumbs.Class("text-sm"))
+
+		t.Run(`it renders a labelled <nav> with an ordered list of links, the last being the current page`, func(t *testing.T) {
+			assert.Equal(t, s, `<nav aria-label="Breadcrumb" class="text-sm"><ol>`+
+				`<li><a href="/">Home</a></li>`+
+				`<li><a href="/docs">Docs</a></li>`+
+				`<li><a href="/docs/forms" aria-current="page">Forms &amp; fields</a></li>`+
+				`</ol></nav>`)
+		})
+	})
+
+	t.Run("Rendering Breadcrumbs with structured data", func(t *testing.T) {
+		body := new(bytes.Buffer)
+		head := new(bytes.Buffer)
+		err := RenderWithHead(body, head, breadcrumbs.StructuredData("https://example.org/"))
+
+		t.Run(`it adds a BreadcrumbList as JSON-LD to the <head>`, func(t *testing.T) {
+			assert.NilError(t, err)
+			assert.Equal(t, head.String(), `<script type="application/ld+json">{"@context":"https://schema.org","@type":"BreadcrumbList","itemListElement":[`+
+				`{"@type":"ListItem","position":1,"name":"Home","item":"https://example.org/"},`+
+				`{"@type":"ListItem","position":2,"name":"Docs","item":"https://example.org/docs"},`+
+				`{"@type":"ListItem","position":3,"name":"Forms \u0026 fields","item":"https://example.org/docs/forms"}`+
+				`]}</script>`)
+		})
+	})
+}
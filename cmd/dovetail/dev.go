@@ -0,0 +1,327 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:generate go run gen_index.go
+
+type devConfig struct {
+	mode      string
+	addr      string
+	appPort   int
+	pkg       string
+	dir       string
+	indexPath string
+	interval  time.Duration
+}
+
+// devBuilder rebuilds the app and makes the new build live
+type devBuilder interface {
+	Rebuild() error
+	http.Handler
+	Close()
+}
+
+func runDev(args []string) error {
+	var config devConfig
+	flags := flag.NewFlagSet("dev", flag.ExitOnError)
+	flags.StringVar(&config.mode, "mode", "wasm", "build `mode`: wasm compiles main.wasm for the browser, server runs the package as a web server")
+	flags.StringVar(&config.addr, "addr", "localhost:8080", "`address` to serve on")
+	flags.IntVar(&config.appPort, "app-port", 8081, "`port` passed to the app server as $PORT in server mode")
+	flags.StringVar(&config.pkg, "pkg", ".", "`package` to build")
+	flags.StringVar(&config.dir, "dir", ".", "`directory` to watch for changes")
+	flags.StringVar(&config.indexPath, "index", filepath.Join("wasm", "src", "index.html"), "`path` to the index.html served in wasm mode")
+	flags.DurationVar(&config.interval, "interval", 500*time.Millisecond, "how often to poll for changes")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage: dovetail dev [flags]\n\nRebuilds on changes to Go sources and reloads open browsers.\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	buildDir, err := ioutil.TempDir("", "dovetail-dev")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(buildDir)
+
+	var builder devBuilder
+	switch config.mode {
+	case "wasm":
+		builder, err = newWasmBuilder(config, buildDir)
+	case "server":
+		builder, err = newServerBuilder(config, buildDir)
+	default:
+		err = fmt.Errorf("unknown mode %q, expected wasm or server", config.mode)
+	}
+	if err != nil {
+		return err
+	}
+	defer builder.Close()
+
+	reloader := newReloader()
+	rebuild := func() {
+		start := time.Now()
+		if err := builder.Rebuild(); err != nil {
+			log.Printf("build failed:\n%v", err)
+			reloader.Broadcast("build-error", err.Error())
+			return
+		}
+		log.Printf("built in %v", time.Since(start).Round(time.Millisecond))
+		reloader.Broadcast("reload", "")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(reloadEventsPath, reloader)
+	mux.Handle("/", builder)
+
+	listener, err := net.Listen("tcp", config.addr)
+	if err != nil {
+		return err
+	}
+	log.Printf("serving on http://%s", listener.Addr())
+
+	serverErrs := make(chan error, 1)
+	go func() {
+		serverErrs <- http.Serve(listener, mux)
+	}()
+
+	rebuild()
+
+	changes := make(chan struct{})
+	watchErrs := make(chan error)
+	stop := make(chan struct{})
+	defer close(stop)
+	go newWatcher(config.dir, config.interval, buildDir).Watch(changes, watchErrs, stop)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	for {
+		select {
+		case <-changes:
+			rebuild()
+		case err := <-watchErrs:
+			log.Printf("watching failed: %v", err)
+		case err := <-serverErrs:
+			return err
+		case <-interrupt:
+			return nil
+		}
+	}
+}
+
+// goBuild compiles pkg to output, returning the compiler output as the error on failure
+func goBuild(pkg string, output string, env ...string) error {
+	cmd := exec.Command("go", "build", "-o", output, pkg)
+	cmd.Env = append(os.Environ(), env...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if len(out) > 0 {
+			return errors.New(strings.TrimSpace(string(out)))
+		}
+		return err
+	}
+	return nil
+}
+
+// findWasmExec locates wasm_exec.js in the Go installation
+func findWasmExec() (string, error) {
+	out, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		return "", err
+	}
+	goroot := strings.TrimSpace(string(out))
+
+	for _, candidate := range []string{
+		filepath.Join(goroot, "lib", "wasm", "wasm_exec.js"),
+		filepath.Join(goroot, "misc", "wasm", "wasm_exec.js"),
+	} {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("wasm_exec.js not found in %s", goroot)
+}
+
+// wasmBuilder compiles the package to main.wasm and serves it with wasm_exec.js and index.html
+type wasmBuilder struct {
+	config    devConfig
+	wasmPath  string
+	wasmExec  string
+	staticDir http.Handler
+}
+
+func newWasmBuilder(config devConfig, buildDir string) (*wasmBuilder, error) {
+	wasmExec, err := findWasmExec()
+	if err != nil {
+		return nil, err
+	}
+
+	return &wasmBuilder{
+		config:    config,
+		wasmPath:  filepath.Join(buildDir, "main.wasm"),
+		wasmExec:  wasmExec,
+		staticDir: http.FileServer(http.Dir(filepath.Dir(config.indexPath))),
+	}, nil
+}
+
+func (builder *wasmBuilder) Rebuild() error {
+	return goBuild(builder.config.pkg, builder.wasmPath, "GOOS=js", "GOARCH=wasm")
+}
+
+func (builder *wasmBuilder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Cache-Control", "no-cache")
+
+	switch req.URL.Path {
+	case "/", "/index.html":
+		page, err := ioutil.ReadFile(builder.config.indexPath)
+		if os.IsNotExist(err) {
+			page = []byte(defaultIndexHTML)
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(injectReloadScript(page, ""))
+	case "/wasm_exec.js":
+		http.ServeFile(w, req, builder.wasmExec)
+	case "/main.wasm":
+		w.Header().Set("Content-Type", "application/wasm")
+		http.ServeFile(w, req, builder.wasmPath)
+	default:
+		builder.staticDir.ServeHTTP(w, req)
+	}
+}
+
+func (builder *wasmBuilder) Close() {}
+
+// serverBuilder compiles the package to a binary, runs it, and proxies requests to it
+type serverBuilder struct {
+	config     devConfig
+	binaryPath string
+	proxy      *httputil.ReverseProxy
+
+	mu  sync.Mutex
+	cmd *exec.Cmd
+}
+
+func newServerBuilder(config devConfig, buildDir string) (*serverBuilder, error) {
+	appURL, err := url.Parse("http://localhost:" + strconv.Itoa(config.appPort))
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(appURL)
+	director := proxy.Director
+	proxy.Director = func(req *http.Request) {
+		director(req)
+		// Ask for an uncompressed body so the reload script can be injected
+		req.Header.Del("Accept-Encoding")
+	}
+	proxy.ModifyResponse = func(res *http.Response) error {
+		if !strings.HasPrefix(res.Header.Get("Content-Type"), "text/html") || res.Header.Get("Content-Encoding") != "" {
+			return nil
+		}
+
+		page, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		// Pages rendered with a nonce only run scripts with the same nonce
+		page = injectReloadScript(page, scriptNonce(res.Header.Get("Content-Security-Policy")))
+		res.Body = ioutil.NopCloser(bytes.NewReader(page))
+		res.ContentLength = int64(len(page))
+		res.Header.Set("Content-Length", strconv.Itoa(len(page)))
+		return nil
+	}
+
+	binaryName := "server"
+	if runtime.GOOS == "windows" {
+		binaryName += ".exe"
+	}
+
+	return &serverBuilder{
+		config:     config,
+		binaryPath: filepath.Join(buildDir, binaryName),
+		proxy:      proxy,
+	}, nil
+}
+
+func (builder *serverBuilder) Rebuild() error {
+	if err := goBuild(builder.config.pkg, builder.binaryPath); err != nil {
+		return err
+	}
+
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+
+	builder.stop()
+
+	cmd := exec.Command(builder.binaryPath)
+	cmd.Env = append(os.Environ(), "PORT="+strconv.Itoa(builder.config.appPort))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	builder.cmd = cmd
+
+	return waitForPort(builder.config.appPort, 10*time.Second)
+}
+
+// stop kills the running server, if any. The caller must hold mu.
+func (builder *serverBuilder) stop() {
+	if builder.cmd == nil {
+		return
+	}
+	builder.cmd.Process.Kill()
+	builder.cmd.Wait()
+	builder.cmd = nil
+}
+
+func (builder *serverBuilder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	builder.proxy.ServeHTTP(w, req)
+}
+
+func (builder *serverBuilder) Close() {
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+
+	builder.stop()
+}
+
+// waitForPort blocks until something accepts connections on port, so browsers reload into a running server
+func waitForPort(port int, timeout time.Duration) error {
+	address := "localhost:" + strconv.Itoa(port)
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		conn, err := net.DialTimeout("tcp", address, 100*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return fmt.Errorf("server did not listen on %s within %v", address, timeout)
+}
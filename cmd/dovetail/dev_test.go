@@ -0,0 +1,16 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestDefaultIndexHTML(t *testing.T) {
+	t.Run(`it matches wasm/src/index.html, run go generate if not`, func(t *testing.T) {
+		page, err := ioutil.ReadFile("../../wasm/src/index.html")
+		assert.NilError(t, err)
+		assert.Equal(t, defaultIndexHTML, string(page))
+	})
+}
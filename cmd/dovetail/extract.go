@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/RoyalIcing/dovetail"
+)
+
+const dovetailImportPath = "github.com/RoyalIcing/dovetail"
+
+func runExtract(args []string) error {
+	flags := flag.NewFlagSet("extract", flag.ExitOnError)
+	dir := flags.String("dir", ".", "`directory` of Go sources to search")
+	out := flags.String("out", "", "JSON catalog `path` to update, or standard output if empty")
+	locale := flags.String("locale", "", "`locale` of the catalog, whose plural forms new plurals get, or the -out file name such as \"fr\" for fr.json if empty")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage: dovetail extract [flags]\n\nFinds the message keys used with T and Plural, and adds any missing ones to a JSON catalog.\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	keys, err := extractMessageKeys(*dir)
+	if err != nil {
+		return err
+	}
+
+	var existing []byte
+	if *out != "" {
+		existing, err = ioutil.ReadFile(*out)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if *locale == "" {
+		*locale = strings.TrimSuffix(filepath.Base(*out), filepath.Ext(*out))
+	}
+	catalog, err := mergeMessageKeys(existing, keys, dovetail.PluralCategories(*locale))
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(catalog)
+		return err
+	}
+	return ioutil.WriteFile(*out, catalog, 0644)
+}
+
+// extractMessageKeys finds the string literal keys passed to dovetail’s T and Plural in Go sources within dir.
+// Each key maps to whether it was used with Plural.
+func extractMessageKeys(dir string) (map[string]bool, error) {
+	keys := make(map[string]bool)
+	fset := token.NewFileSet()
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name := info.Name()
+		if info.IsDir() {
+			if path != dir && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		pkgName, dotImported := dovetailImportName(file)
+		if pkgName == "" && !dotImported {
+			return nil
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+
+			// Only calls resolving to the dovetail package count, not another package’s T
+			var funcName string
+			switch fun := call.Fun.(type) {
+			case *ast.Ident:
+				if dotImported && fun.Obj == nil {
+					funcName = fun.Name
+				}
+			case *ast.SelectorExpr:
+				if x, ok := fun.X.(*ast.Ident); ok && x.Name == pkgName && x.Obj == nil {
+					funcName = fun.Sel.Name
+				}
+			}
+			if funcName != "T" && funcName != "Plural" {
+				return true
+			}
+
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			key, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return true
+			}
+			keys[key] = keys[key] || funcName == "Plural"
+			return true
+		})
+		return nil
+	})
+	return keys, err
+}
+
+// dovetailImportName returns the name a file imports dovetail as, or whether it is dot-imported.
+// Files within package dovetail itself call T and Plural directly.
+func dovetailImportName(file *ast.File) (name string, dotImported bool) {
+	if file.Name.Name == "dovetail" {
+		return "", true
+	}
+	for _, imp := range file.Imports {
+		if path, err := strconv.Unquote(imp.Path.Value); err != nil || path != dovetailImportPath {
+			continue
+		}
+		switch {
+		case imp.Name == nil:
+			name = "dovetail"
+		case imp.Name.Name == ".":
+			dotImported = true
+		case imp.Name.Name != "_":
+			name = imp.Name.Name
+		}
+	}
+	return name, dotImported
+}
+
+// mergeMessageKeys adds keys missing from an existing JSON catalog, keeping its translations.
+// New messages are empty, with a form for each of the plural categories for plurals.
+func mergeMessageKeys(existing []byte, keys map[string]bool, pluralCategories []string) ([]byte, error) {
+	messages := make(map[string]json.RawMessage)
+	if len(bytes.TrimSpace(existing)) > 0 {
+		if err := json.Unmarshal(existing, &messages); err != nil {
+			return nil, fmt.Errorf("reading catalog: %v", err)
+		}
+	}
+
+	// Written by hand to keep the forms in CLDR order
+	var pluralForms bytes.Buffer
+	pluralForms.WriteByte('{')
+	for i, category := range pluralCategories {
+		if i > 0 {
+			pluralForms.WriteString(", ")
+		}
+		fmt.Fprintf(&pluralForms, "%q: \"\"", category)
+	}
+	pluralForms.WriteByte('}')
+
+	for key, plural := range keys {
+		if _, ok := messages[key]; ok {
+			continue
+		}
+		if plural {
+			messages[key] = json.RawMessage(pluralForms.Bytes())
+		} else {
+			messages[key] = json.RawMessage(`""`)
+		}
+	}
+
+	// Maps are encoded with sorted keys, so the catalog diffs cleanly
+	b, err := json.MarshalIndent(messages, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
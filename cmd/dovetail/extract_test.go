@@ -0,0 +1,85 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/RoyalIcing/dovetail"
+	"gotest.tools/assert"
+)
+
+func TestExtractMessageKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dovetail-extract")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte(`package main
+
+import . "github.com/RoyalIcing/dovetail"
+
+func view(name string, count int) HTMLView {
+	return Div(
+		T("greeting", Param("name", name)),
+		Plural("cart.items", count),
+		T(name),
+	)
+}
+`), 0644))
+	assert.NilError(t, os.Mkdir(filepath.Join(dir, "pages"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "pages", "about.go"), []byte(`package pages
+
+import "github.com/RoyalIcing/dovetail"
+
+var About = dovetail.H(1, dovetail.T("about.title"))
+`), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "pages", "contact.go"), []byte(`package pages
+
+import (
+	dt "github.com/RoyalIcing/dovetail"
+	"example.org/other"
+)
+
+func contact(dovetail other.Translator) dt.HTMLView {
+	return dt.Div(dt.T("contact.title"), other.T("other.title"), dovetail.T("local.title"), T("unqualified"))
+}
+`), 0644))
+
+	keys, err := extractMessageKeys(dir)
+	assert.NilError(t, err)
+
+	t.Run(`it finds string literal keys passed to dovetail, noting plurals`, func(t *testing.T) {
+		assert.DeepEqual(t, keys, map[string]bool{"greeting": false, "cart.items": true, "about.title": false, "contact.title": false})
+	})
+
+	t.Run(`it adds missing keys while keeping existing translations`, func(t *testing.T) {
+		merged, err := mergeMessageKeys([]byte(`{"greeting": "Hello, {name}!", "old": "Kept"}`), keys, dovetail.PluralCategories("en"))
+		assert.NilError(t, err)
+		assert.Equal(t, string(merged), `{
+  "about.title": "",
+  "cart.items": {
+    "one": "",
+    "other": ""
+  },
+  "contact.title": "",
+  "greeting": "Hello, {name}!",
+  "old": "Kept"
+}
+`)
+	})
+
+	t.Run(`it adds the locale’s plural forms`, func(t *testing.T) {
+		merged, err := mergeMessageKeys(nil, map[string]bool{"cart.items": true}, dovetail.PluralCategories("ru"))
+		assert.NilError(t, err)
+		assert.Equal(t, string(merged), `{
+  "cart.items": {
+    "one": "",
+    "few": "",
+    "many": "",
+    "other": ""
+  }
+}
+`)
+	})
+}
@@ -0,0 +1,37 @@
+// +build ignore
+
+// Generates index_html.go from wasm/src/index.html, so the dev server’s built-in page matches the wasm example’s
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+)
+
+const sourcePath = "../../wasm/src/index.html"
+
+func main() {
+	page, err := ioutil.ReadFile(sourcePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if bytes.IndexByte(page, '`') >= 0 {
+		log.Fatalf("%s contains a backquote, which can’t be in a raw string literal", sourcePath)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by go run gen_index.go; DO NOT EDIT.\n\npackage main\n\n")
+	fmt.Fprintf(&b, "// defaultIndexHTML is served in wasm mode when the app has no index.html of its own, generated from wasm/src/index.html\n")
+	fmt.Fprintf(&b, "const defaultIndexHTML = `%s`\n", page)
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("index_html.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,48 @@
+// Code generated by go run gen_index.go; DO NOT EDIT.
+
+package main
+
+// defaultIndexHTML is served in wasm mode when the app has no index.html of its own, generated from wasm/src/index.html
+const defaultIndexHTML = `<!doctype html>
+<html>
+  <head>
+    <meta charset="utf-8"/>
+    <script src="wasm_exec.js"></script>
+    <script>
+      if (!WebAssembly.instantiateStreaming) { // polyfill
+        WebAssembly.instantiateStreaming = async (resp, importObject) => {
+          const source = await (await resp).arrayBuffer();
+          return await WebAssembly.instantiate(source, importObject);
+        };
+      }
+
+      const go = new Go();
+      WebAssembly.instantiateStreaming(fetch("main.wasm"), go.importObject).then((result) => {
+        go.run(result.instance);
+      });
+
+      function updateHead(html) {
+        document.head.querySelectorAll('.dovetail-managed').forEach((el) => el.remove());
+
+        const template = document.createElement('template');
+        template.innerHTML = html;
+
+        for (const el of Array.from(template.content.children)) {
+          el.classList.add('dovetail-managed');
+          if (el.tagName === 'TITLE') {
+            document.title = el.textContent;
+          }
+          document.head.appendChild(el);
+        }
+      }
+
+      function updateBody(html) {
+        document.getElementById("wasm").innerHTML = html;
+      }
+    </script>
+  </head>
+  <body>
+    <div id="wasm"></div>
+  </body>
+</html>
+`
@@ -0,0 +1,40 @@
+// Command dovetail provides tools for developing apps with Dovetail
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `Usage: dovetail <command> [arguments]
+
+Commands:
+  dev      run a development server that rebuilds and live reloads on changes
+  extract  add the message keys used with T and Plural to a JSON catalog
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "dev":
+		err = runDev(os.Args[2:])
+	case "extract":
+		err = runExtract(os.Args[2:])
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(os.Stdout, usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "dovetail: unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "dovetail: %v\n", err)
+		os.Exit(1)
+	}
+}
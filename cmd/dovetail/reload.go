@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// reloadEventsPath is where browsers subscribe to reload notifications
+const reloadEventsPath = "/_dovetail/events"
+
+// reloadScriptSource is injected into every HTML page served by the development server
+const reloadScriptSource = `
+(function() {
+  var source = new EventSource("` + reloadEventsPath + `");
+  source.addEventListener("reload", function() { location.reload(); });
+  source.addEventListener("build-error", function(event) { console.error("dovetail: build failed\n" + event.data); });
+})();
+`
+
+// reloadScript makes the <script> for the reload source, with the nonce allowing it under the page’s Content-Security-Policy
+func reloadScript(nonce string) string {
+	if nonce == "" {
+		return "<script>" + reloadScriptSource + "</script>"
+	}
+	return `<script nonce="` + html.EscapeString(nonce) + `">` + reloadScriptSource + "</script>"
+}
+
+// scriptNonce finds the nonce allowing inline scripts in a Content-Security-Policy header, or "" if there is none
+func scriptNonce(policy string) string {
+	var defaultNonce string
+	for _, directive := range strings.Split(policy, ";") {
+		fields := strings.Fields(directive)
+		if len(fields) == 0 || (fields[0] != "script-src" && fields[0] != "default-src") {
+			continue
+		}
+		for _, source := range fields[1:] {
+			if strings.HasPrefix(source, "'nonce-") && strings.HasSuffix(source, "'") {
+				nonce := source[len("'nonce-") : len(source)-1]
+				if fields[0] == "script-src" {
+					return nonce
+				}
+				defaultNonce = nonce
+			}
+		}
+	}
+	return defaultNonce
+}
+
+// reloadEvent is a Server-Sent Event pushed to connected browsers
+type reloadEvent struct {
+	name string
+	data string
+}
+
+// reloader pushes reload events to browsers over Server-Sent Events
+type reloader struct {
+	mu      sync.Mutex
+	clients map[chan reloadEvent]struct{}
+}
+
+func newReloader() *reloader {
+	return &reloader{clients: make(map[chan reloadEvent]struct{})}
+}
+
+// Broadcast sends an event to every connected browser
+func (r *reloader) Broadcast(name string, data string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for client := range r.clients {
+		select {
+		case client <- reloadEvent{name: name, data: data}:
+		default:
+			// The browser is not keeping up, it will get the next event
+		}
+	}
+}
+
+func (r *reloader) subscribe() chan reloadEvent {
+	client := make(chan reloadEvent, 1)
+	r.mu.Lock()
+	r.clients[client] = struct{}{}
+	r.mu.Unlock()
+	return client
+}
+
+func (r *reloader) unsubscribe(client chan reloadEvent) {
+	r.mu.Lock()
+	delete(r.clients, client)
+	r.mu.Unlock()
+}
+
+func (r *reloader) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	client := r.subscribe()
+	defer r.unsubscribe(client)
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case event := <-client:
+			fmt.Fprintf(w, "event: %s\n", event.name)
+			for _, line := range strings.Split(event.data, "\n") {
+				fmt.Fprintf(w, "data: %s\n", line)
+			}
+			fmt.Fprint(w, "\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// injectReloadScript adds the reload script to an HTML page, just before </body> if present.
+// The nonce is the page’s Content-Security-Policy nonce, or "" if it has none.
+func injectReloadScript(page []byte, nonce string) []byte {
+	script := reloadScript(nonce)
+	closingBody := []byte("</body>")
+	index := bytes.LastIndex(bytes.ToLower(page), closingBody)
+	if index == -1 {
+		return append(page, script...)
+	}
+
+	injected := make([]byte, 0, len(page)+len(script))
+	injected = append(injected, page[:index]...)
+	injected = append(injected, script...)
+	injected = append(injected, page[index:]...)
+	return injected
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestInjectReloadScript(t *testing.T) {
+	t.Run(`it injects before the closing body tag`, func(t *testing.T) {
+		page := string(injectReloadScript([]byte(`<html><body><p>Hi</p></BODY></html>`), ""))
+
+		assert.Equal(t, page, `<html><body><p>Hi</p><script>`+reloadScriptSource+`</script></BODY></html>`)
+	})
+
+	t.Run(`it appends to a page without a body tag`, func(t *testing.T) {
+		page := string(injectReloadScript([]byte(`<p>Hi</p>`), ""))
+
+		assert.Equal(t, page, `<p>Hi</p><script>`+reloadScriptSource+`</script>`)
+	})
+
+	t.Run(`it adds the page’s nonce to the script`, func(t *testing.T) {
+		page := string(injectReloadScript([]byte(`<p>Hi</p>`), "abc123"))
+
+		assert.Equal(t, page, `<p>Hi</p><script nonce="abc123">`+reloadScriptSource+`</script>`)
+	})
+}
+
+func TestScriptNonce(t *testing.T) {
+	t.Run(`it finds the nonce in script-src`, func(t *testing.T) {
+		assert.Equal(t, scriptNonce(`default-src 'self'; script-src 'self' 'nonce-abc+/='; object-src 'none'`), "abc+/=")
+	})
+
+	t.Run(`it falls back to default-src`, func(t *testing.T) {
+		assert.Equal(t, scriptNonce(`default-src 'self' 'nonce-xyz'`), "xyz")
+	})
+
+	t.Run(`it returns nothing without a nonce`, func(t *testing.T) {
+		assert.Equal(t, scriptNonce(`script-src 'self'`), "")
+		assert.Equal(t, scriptNonce(""), "")
+	})
+}
+
+func TestReloader(t *testing.T) {
+	reloader := newReloader()
+	server := httptest.NewServer(reloader)
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	assert.NilError(t, err)
+	defer res.Body.Close()
+
+	t.Run(`it streams Server-Sent Events`, func(t *testing.T) {
+		assert.Equal(t, res.Header.Get("Content-Type"), "text/event-stream")
+	})
+
+	t.Run(`it sends broadcast events to connected browsers`, func(t *testing.T) {
+		// Wait for the browser to be subscribed
+		for i := 0; i < 100; i++ {
+			reloader.mu.Lock()
+			subscribed := len(reloader.clients) > 0
+			reloader.mu.Unlock()
+			if subscribed {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		reloader.Broadcast("build-error", "line one\nline two")
+
+		reader := bufio.NewReader(res.Body)
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			assert.NilError(t, err)
+			if line == "\n" {
+				break
+			}
+			lines = append(lines, line)
+		}
+
+		assert.Equal(t, strings.Join(lines, ""), "event: build-error\ndata: line one\ndata: line two\n")
+	})
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// watcher polls a directory tree for changes to source files.
+// Polling avoids platform-specific file notification APIs, and is fast enough for a typical app.
+type watcher struct {
+	root       string
+	extensions []string
+	ignore     []string
+	interval   time.Duration
+	snapshot   map[string]time.Time
+}
+
+func newWatcher(root string, interval time.Duration, ignore ...string) *watcher {
+	return &watcher{
+		root:       root,
+		extensions: []string{".go", ".html", ".css", ".js"},
+		ignore:     ignore,
+		interval:   interval,
+	}
+}
+
+func (w *watcher) isIgnored(path string) bool {
+	for _, ignored := range w.ignore {
+		if ignored == "" {
+			continue
+		}
+		if path == ignored || strings.HasPrefix(path, ignored+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *watcher) isWatched(path string) bool {
+	ext := filepath.Ext(path)
+	for _, watched := range w.extensions {
+		if ext == watched {
+			return true
+		}
+	}
+	return false
+}
+
+// scan returns the modification time of every watched file
+func (w *watcher) scan() (map[string]time.Time, error) {
+	files := make(map[string]time.Time)
+	err := filepath.Walk(w.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			name := info.Name()
+			if path != w.root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			if w.isIgnored(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if w.isWatched(path) && !w.isIgnored(path) {
+			files[path] = info.ModTime()
+		}
+		return nil
+	})
+	return files, err
+}
+
+// changed scans the tree and reports whether any watched file was added, removed or modified since the last call
+func (w *watcher) changed() (bool, error) {
+	files, err := w.scan()
+	if err != nil {
+		return false, err
+	}
+
+	previous := w.snapshot
+	w.snapshot = files
+	if previous == nil {
+		return false, nil
+	}
+
+	if len(previous) != len(files) {
+		return true, nil
+	}
+	for path, modTime := range files {
+		if previousModTime, ok := previous[path]; !ok || !previousModTime.Equal(modTime) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Watch sends to changes whenever the tree changes, until stop is closed
+func (w *watcher) Watch(changes chan<- struct{}, errs chan<- error, stop <-chan struct{}) {
+	if _, err := w.changed(); err != nil {
+		errs <- err
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			changed, err := w.changed()
+			if err != nil {
+				errs <- err
+				continue
+			}
+			if changed {
+				changes <- struct{}{}
+			}
+		}
+	}
+}
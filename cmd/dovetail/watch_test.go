@@ -0,0 +1,70 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dovetail-watch")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	mainPath := filepath.Join(dir, "main.go")
+	assert.NilError(t, ioutil.WriteFile(mainPath, []byte("package main"), 0644))
+	assert.NilError(t, os.Mkdir(filepath.Join(dir, ".git"), 0755))
+
+	w := newWatcher(dir, time.Millisecond)
+	changed, err := w.changed()
+	assert.NilError(t, err)
+
+	t.Run(`it does not report a change on the first scan`, func(t *testing.T) {
+		assert.Equal(t, changed, false)
+	})
+
+	t.Run(`it ignores files that are not sources`, func(t *testing.T) {
+		assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0644))
+
+		changed, err := w.changed()
+		assert.NilError(t, err)
+		assert.Equal(t, changed, false)
+	})
+
+	t.Run(`it ignores hidden directories`, func(t *testing.T) {
+		assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, ".git", "hook.go"), []byte("package git"), 0644))
+
+		changed, err := w.changed()
+		assert.NilError(t, err)
+		assert.Equal(t, changed, false)
+	})
+
+	t.Run(`it reports a modified Go file`, func(t *testing.T) {
+		later := time.Now().Add(time.Minute)
+		assert.NilError(t, os.Chtimes(mainPath, later, later))
+
+		changed, err := w.changed()
+		assert.NilError(t, err)
+		assert.Equal(t, changed, true)
+	})
+
+	t.Run(`it reports an added Go file`, func(t *testing.T) {
+		assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "view.go"), []byte("package main"), 0644))
+
+		changed, err := w.changed()
+		assert.NilError(t, err)
+		assert.Equal(t, changed, true)
+	})
+
+	t.Run(`it reports a removed Go file`, func(t *testing.T) {
+		assert.NilError(t, os.Remove(filepath.Join(dir, "view.go")))
+
+		changed, err := w.changed()
+		assert.NilError(t, err)
+		assert.Equal(t, changed, true)
+	})
+}
@@ -0,0 +1,125 @@
+package dovetail
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+)
+
+type cspNonceKey struct{}
+
+// WithCSPNonce returns a context carrying nonce, which is added to inline scripts and styles rendered with RenderContext
+func WithCSPNonce(ctx context.Context, nonce string) context.Context {
+	return context.WithValue(ctx, cspNonceKey{}, nonce)
+}
+
+// CSPNonce returns the nonce for the request, or "" if there is none
+func CSPNonce(ctx context.Context) string {
+	nonce, _ := ctx.Value(cspNonceKey{}).(string)
+	return nonce
+}
+
+// newCSPNonce makes a random nonce that can’t be guessed by an attacker
+func newCSPNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// CSPHash returns the source expression for an inline script or style, such as 'sha256-…'
+func CSPHash(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"
+}
+
+type cspDirective struct {
+	name    string
+	sources []string
+}
+
+// ContentSecurityPolicy makes the Content-Security-Policy header, allowing inline scripts and styles by nonce or hash
+type ContentSecurityPolicy struct {
+	directives   []cspDirective
+	scriptHashes []string
+	styleHashes  []string
+}
+
+// NewContentSecurityPolicy makes a strict policy that only loads content from the same origin,
+// and only runs inline scripts and styles rendered in the request
+func NewContentSecurityPolicy() ContentSecurityPolicy {
+	return ContentSecurityPolicy{}.
+		Directive("default-src", "'self'").
+		Directive("script-src", "'self'").
+		Directive("style-src", "'self'").
+		Directive("object-src", "'none'").
+		Directive("base-uri", "'self'")
+}
+
+// Directive sets the sources for a directive such as "img-src", replacing any previous sources
+func (csp ContentSecurityPolicy) Directive(name string, sources ...string) ContentSecurityPolicy {
+	directives := make([]cspDirective, 0, len(csp.directives)+1)
+	replaced := false
+	for _, directive := range csp.directives {
+		if directive.name == name {
+			directive.sources = sources
+			replaced = true
+		}
+		directives = append(directives, directive)
+	}
+	if !replaced {
+		directives = append(directives, cspDirective{name: name, sources: sources})
+	}
+	csp.directives = directives
+	return csp
+}
+
+// HashScript allows a static inline script by its hash, so it runs even when cached without the nonce
+func (csp ContentSecurityPolicy) HashScript(source string) ContentSecurityPolicy {
+	csp.scriptHashes = append(csp.scriptHashes[:len(csp.scriptHashes):len(csp.scriptHashes)], CSPHash(source))
+	return csp
+}
+
+// HashStyle allows a static inline style by its hash
+func (csp ContentSecurityPolicy) HashStyle(source string) ContentSecurityPolicy {
+	csp.styleHashes = append(csp.styleHashes[:len(csp.styleHashes):len(csp.styleHashes)], CSPHash(source))
+	return csp
+}
+
+// Header returns the value of the Content-Security-Policy header, with the nonce added to script-src and style-src
+func (csp ContentSecurityPolicy) Header(nonce string) string {
+	parts := make([]string, 0, len(csp.directives))
+	for _, directive := range csp.directives {
+		sources := directive.sources[:len(directive.sources):len(directive.sources)]
+		switch directive.name {
+		case "script-src":
+			sources = append(sources, csp.scriptHashes...)
+		case "style-src":
+			sources = append(sources, csp.styleHashes...)
+		}
+		if nonce != "" && (directive.name == "script-src" || directive.name == "style-src") {
+			sources = append(sources, "'nonce-"+nonce+"'")
+		}
+		parts = append(parts, strings.TrimSpace(directive.name+" "+strings.Join(sources, " ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Middleware generates a nonce for each request, sets the Content-Security-Policy header,
+// and passes the nonce to next within the request’s context, to be used by RenderContext
+func (csp ContentSecurityPolicy) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonce, err := newCSPNonce()
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Security-Policy", csp.Header(nonce))
+		next.ServeHTTP(w, r.WithContext(WithCSPNonce(r.Context(), nonce)))
+	})
+}
@@ -0,0 +1,85 @@
+package dovetail
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestInlineScriptAndStyle(t *testing.T) {
+	t.Run("Rendering without a nonce", func(t *testing.T) {
+		s := subjectAsString(Div(InlineScript(`if (a < b) go()`), InlineStyle(`p > em { color: red }`)))
+
+		t.Run(`it renders the source as-is`, func(t *testing.T) {
+			assert.Equal(t, s, `<div><script>if (a < b) go()</script><style>p > em { color: red }</style></div>`)
+		})
+	})
+
+	t.Run("Rendering with a nonce in the context", func(t *testing.T) {
+		b := new(bytes.Buffer)
+		err := RenderContext(WithCSPNonce(context.Background(), "abc123"), b, Document(Head(InlineStyle(`body { margin: 0 }`)), InlineScript(`go()`)))
+
+		t.Run(`it adds the nonce to inline scripts and styles`, func(t *testing.T) {
+			assert.NilError(t, err)
+			assert.Assert(t, strings.Contains(b.String(), `<style nonce="abc123">body { margin: 0 }</style>`), b.String())
+			assert.Assert(t, strings.Contains(b.String(), `<script nonce="abc123">go()</script>`), b.String())
+		})
+	})
+
+	t.Run("Rendering a script that closes itself", func(t *testing.T) {
+		b := new(bytes.Buffer)
+		err := Render(b, InlineScript(`"</SCRIPT><script>alert(1)"`))
+
+		t.Run(`it leaves out the source and returns an error`, func(t *testing.T) {
+			assert.Equal(t, b.String(), `<script></script>`)
+			assert.Error(t, err, `dovetail: inline <script> must not contain </script`)
+		})
+	})
+}
+
+func TestContentSecurityPolicy(t *testing.T) {
+	t.Run("Making the header", func(t *testing.T) {
+		csp := NewContentSecurityPolicy().
+			Directive("img-src", "'self'", "https://images.example.org").
+			Directive("object-src").
+			HashScript(`go()`)
+
+		t.Run(`it adds the nonce and hashes to script-src and style-src`, func(t *testing.T) {
+			assert.Equal(t, csp.Header("abc123"), `default-src 'self'; script-src 'self' `+CSPHash(`go()`)+` 'nonce-abc123'; style-src 'self' 'nonce-abc123'; object-src; base-uri 'self'; img-src 'self' https://images.example.org`)
+		})
+	})
+
+	t.Run("Hashing inline source", func(t *testing.T) {
+		t.Run(`it uses base64-encoded SHA-256`, func(t *testing.T) {
+			assert.Equal(t, CSPHash(`alert('Hello, world.');`), `'sha256-qznLcsROx4GACP2dm0UCKCzCG+HiZ1guq6ZZDob/Tng='`)
+		})
+	})
+
+	t.Run("Serving a request through the middleware", func(t *testing.T) {
+		handler := NewContentSecurityPolicy().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			RenderContext(r.Context(), w, InlineScript(`go()`))
+		}))
+		first := httptest.NewRecorder()
+		handler.ServeHTTP(first, httptest.NewRequest("GET", "/", nil))
+		second := httptest.NewRecorder()
+		handler.ServeHTTP(second, httptest.NewRequest("GET", "/", nil))
+
+		header := first.Header().Get("Content-Security-Policy")
+		nonce := header[strings.Index(header, "'nonce-")+len("'nonce-"):]
+		nonce = nonce[:strings.Index(nonce, "'")]
+
+		t.Run(`it renders with the nonce in the header`, func(t *testing.T) {
+			assert.Equal(t, len(nonce), 24)
+			assert.Equal(t, first.Body.String(), `<script nonce="`+nonce+`">go()</script>`)
+		})
+
+		t.Run(`it makes a new nonce for each request`, func(t *testing.T) {
+			assert.Assert(t, !strings.Contains(second.Header().Get("Content-Security-Policy"), nonce))
+		})
+	})
+}
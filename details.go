@@ -0,0 +1,130 @@
+package dovetail
+
+import (
+	"strconv"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// scriptEnhanced is true in the WebAssembly build, where components can listen to events instead of relying on built-in elements
+var scriptEnhanced = false
+
+// Details makes <details> with <summary>, which shows its children when the summary is clicked
+func Details(summary HTMLView, children ...HTMLView) HTMLElementView {
+	children = append([]HTMLView{Summary(summary)}, children...)
+	return HTMLElementViewOf("details", atom.Details, children)
+}
+
+func Summary(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("summary", atom.Summary, children)
+}
+
+// DetailsOpen shows the children of Details when first rendered
+var DetailsOpen = HTMLAttrView{Key: "open"}
+
+// AccordionSection is a heading and panel within Accordion
+type AccordionSection struct {
+	heading  HTMLView
+	children []HTMLView
+	open     bool
+}
+
+// AccordionItem makes a section for Accordion, with a heading that shows or hides the children
+func AccordionItem(heading HTMLView, children ...HTMLView) AccordionSection {
+	return AccordionSection{heading: heading, children: children}
+}
+
+// Open shows the section’s children when first rendered
+func (section AccordionSection) Open() AccordionSection {
+	section.open = true
+	return section
+}
+
+// AccordionView makes a set of sections that can each be shown or hidden
+type AccordionView struct {
+	id           string
+	headingLevel int
+	sections     []AccordionSection
+	elementCore  HTMLElementCore
+}
+
+// Accordion makes sections that each show or hide when their heading is clicked.
+// Without JavaScript each section is <details>. In the WebAssembly build each is a heading containing a button,
+// with aria-expanded and aria-controls for the panel it shows. The id is used to make ids for each section.
+func Accordion(id string, sections ...AccordionSection) AccordionView {
+	return AccordionView{id: id, headingLevel: 3, sections: sections}
+}
+
+// HeadingLevel changes the level of the sections’ headings in the WebAssembly build, which defaults to 3
+func (accordion AccordionView) HeadingLevel(level int) AccordionView {
+	accordion.headingLevel = level
+	return accordion
+}
+
+// Use the provided enhancers
+func (accordion AccordionView) Use(enhancers ...HTMLEnhancer) AccordionView {
+	accordion.elementCore = accordion.elementCore.Use(enhancers...)
+	return accordion
+}
+
+func (accordion AccordionView) Class(classNames ...string) AccordionView {
+	accordion.elementCore.classNames = append(accordion.elementCore.classNames, classNames...)
+	return accordion
+}
+
+func (accordion AccordionView) sectionID(index int) string {
+	return accordion.id + "-" + strconv.Itoa(index+1)
+}
+
+// detailsViews renders each section as <details>, which works without JavaScript
+func (accordion AccordionView) detailsViews() []HTMLView {
+	views := make([]HTMLView, 0, len(accordion.sections))
+	for index, section := range accordion.sections {
+		details := Details(section.heading, section.children...).
+			Use(CustomAttr("id", accordion.sectionID(index))).
+			UseWhen(section.open, DetailsOpen)
+		views = append(views, details)
+	}
+	return views
+}
+
+// enhancedViews renders each section as a heading with a button controlling a panel, following the ARIA accordion pattern
+func (accordion AccordionView) enhancedViews() []HTMLView {
+	views := make([]HTMLView, 0, len(accordion.sections)*2)
+	for index, section := range accordion.sections {
+		id := accordion.sectionID(index)
+		expanded := "false"
+		if section.open {
+			expanded = "true"
+		}
+
+		button := Button(section.heading).Use(
+			CustomAttr("id", id+"-button"),
+			AriaAttr("expanded", expanded),
+			AriaAttr("controls", id+"-panel"),
+		)
+		panel := Div(section.children...).
+			Use(CustomAttr("id", id+"-panel"), CustomAttr("role", "region"), AriaAttr("labelledby", id+"-button")).
+			UseWhen(!section.open, CustomAttr("hidden", ""))
+		views = append(views, H(accordion.headingLevel, button), panel)
+	}
+	return views
+}
+
+func (accordion AccordionView) apply(node *html.Node, ctx *buildContext) {
+	node.Type = html.ElementNode
+	node.Data = "div"
+	node.DataAtom = atom.Div
+	node.Attr = []html.Attribute{{Key: "data-accordion", Val: accordion.id}}
+
+	accordion.elementCore.applyToNode(node, ctx)
+
+	views := accordion.detailsViews()
+	if scriptEnhanced {
+		views = accordion.enhancedViews()
+	}
+	for _, view := range views {
+		node.AppendChild(ctx.build(view))
+	}
+}
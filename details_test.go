@@ -0,0 +1,56 @@
+package dovetail
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestDetails(t *testing.T) {
+	t.Run("Rendering Details", func(t *testing.T) {
+		s := subjectAsString(Details(Text("More"), P(Text("Hidden until opened"))))
+
+		t.Run(`it renders <details> with <summary> first`, func(t *testing.T) {
+			assert.Equal(t, s, `<details><summary>More</summary><p>Hidden until opened</p></details>`)
+		})
+	})
+
+	t.Run("Rendering Details that is open", func(t *testing.T) {
+		s := subjectAsString(Details(Text("More"), Text("Shown")).Use(DetailsOpen))
+
+		t.Run(`it adds the open attribute`, func(t *testing.T) {
+			assert.Equal(t, s, `<details open=""><summary>More</summary>Shown</details>`)
+		})
+	})
+}
+
+func TestAccordion(t *testing.T) {
+	accordion := Accordion("faq",
+		AccordionItem(Text("Shipping"), P(Text("Free"))),
+		AccordionItem(Text("Returns"), P(Text("30 days"))).Open(),
+	).Class("divide-y")
+
+	t.Run("Rendering Accordion", func(t *testing.T) {
+		s := subjectAsString(accordion)
+
+		t.Run(`it renders each section as <details>, which works without JavaScript`, func(t *testing.T) {
+			assert.Equal(t, s, `<div data-accordion="faq" class="divide-y">`+
+				`<details id="faq-1"><summary>Shipping</summary><p>Free</p></details>`+
+				`<details id="faq-2" open=""><summary>Returns</summary><p>30 days</p></details>`+
+				`</div>`)
+		})
+	})
+
+	t.Run("Rendering Accordion enhanced by WebAssembly", func(t *testing.T) {
+		s := renderScriptEnhanced(func() HTMLView { return accordion.HeadingLevel(2) })
+
+		t.Run(`it renders headings with buttons controlling panels`, func(t *testing.T) {
+			assert.Equal(t, s, `<div data-accordion="faq" class="divide-y">`+
+				`<h2><button type="button" id="faq-1-button" aria-expanded="false" aria-controls="faq-1-panel">Shipping</button></h2>`+
+				`<div id="faq-1-panel" role="region" aria-labelledby="faq-1-button" hidden=""><p>Free</p></div>`+
+				`<h2><button type="button" id="faq-2-button" aria-expanded="true" aria-controls="faq-2-panel">Returns</button></h2>`+
+				`<div id="faq-2-panel" role="region" aria-labelledby="faq-2-button"><p>30 days</p></div>`+
+				`</div>`)
+		})
+	})
+}
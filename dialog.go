@@ -0,0 +1,124 @@
+package dovetail
+
+import (
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// DialogView makes <dialog> labelled by its heading, with a form of actions that close it
+type DialogView struct {
+	id           string
+	title        HTMLView
+	children     []HTMLView
+	actions      []HTMLView
+	closeLabel   string
+	headingLevel int
+	fallbackURL  string
+	elementCore  HTMLElementCore
+}
+
+// Dialog makes <dialog> with the id, a heading of title that labels it, the children, and a close button.
+// Open it with a Button that uses OpensDialog, or use DialogLink so it also works without JavaScript.
+func Dialog(id string, title HTMLView, children ...HTMLView) DialogView {
+	return DialogView{id: id, title: title, children: children, closeLabel: "Close", headingLevel: 2}
+}
+
+// Actions adds submit buttons, such as to confirm or cancel. The dialog closes with the button’s value as its returnValue.
+func (dialog DialogView) Actions(buttons ...HTMLView) DialogView {
+	dialog.actions = append(dialog.actions[:len(dialog.actions):len(dialog.actions)], buttons...)
+	return dialog
+}
+
+// CloseLabel changes the text of the close button, which defaults to "Close"
+func (dialog DialogView) CloseLabel(label string) DialogView {
+	dialog.closeLabel = label
+	return dialog
+}
+
+// HeadingLevel changes the level of the heading, which defaults to 2
+func (dialog DialogView) HeadingLevel(level int) DialogView {
+	dialog.headingLevel = level
+	return dialog
+}
+
+// Fallback renders the dialog for its own route, which DialogLink links to without JavaScript.
+// The dialog is already open, the actions are posted to the route, and the close button links to returnURL.
+func (dialog DialogView) Fallback(returnURL string) DialogView {
+	dialog.fallbackURL = returnURL
+	return dialog
+}
+
+// Use the provided enhancers
+func (dialog DialogView) Use(enhancers ...HTMLEnhancer) DialogView {
+	dialog.elementCore = dialog.elementCore.Use(enhancers...)
+	return dialog
+}
+
+func (dialog DialogView) Class(classNames ...string) DialogView {
+	dialog.elementCore.classNames = append(dialog.elementCore.classNames, classNames...)
+	return dialog
+}
+
+func (dialog DialogView) apply(node *html.Node, ctx *buildContext) {
+	node.Type = html.ElementNode
+	node.Data = "dialog"
+	node.DataAtom = atom.Dialog
+
+	titleID := dialog.id + "-title"
+	node.Attr = []html.Attribute{{Key: "id", Val: dialog.id}, {Key: "aria-labelledby", Val: titleID}}
+	if dialog.fallbackURL != "" {
+		node.Attr = append(node.Attr, html.Attribute{Key: "open"})
+	}
+
+	dialog.elementCore.applyToNode(node, ctx)
+
+	node.AppendChild(ctx.build(H(dialog.headingLevel, CustomAttr("id", titleID), dialog.title)))
+	for _, child := range dialog.children {
+		if child != nil {
+			appendNode(node, ctx.build(child))
+		}
+	}
+
+	actions := dialog.actions[:len(dialog.actions):len(dialog.actions)]
+	var form HTMLElementView
+	if dialog.fallbackURL == "" {
+		closeButton := SubmitButton(Text(dialog.closeLabel), CustomAttr("value", "close"))
+		form = HTMLElementViewOf("form", atom.Form, append(actions, closeButton)).Use(CustomAttr("method", "dialog"))
+	} else {
+		closeLink := Link(dialog.fallbackURL, Text(dialog.closeLabel))
+		form = HTMLElementViewOf("form", atom.Form, append(actions, closeLink)).Use(CustomAttr("method", "post"))
+	}
+	node.AppendChild(ctx.build(form))
+}
+
+// OpensDialog lets a Button open the dialog with the id as a modal in the WebAssembly build
+func OpensDialog(dialogID string) HTMLEnhancer {
+	return combinedView{views: []HTMLView{AriaAttr("haspopup", "dialog"), DataAttr("opens-dialog", dialogID)}}
+}
+
+// DialogLinkView makes a button opening a dialog in the WebAssembly build, otherwise a link to the dialog’s fallback route
+type DialogLinkView struct {
+	dialogID    string
+	fallbackURL string
+	children    []HTMLView
+	enhancers   []HTMLEnhancer
+}
+
+// DialogLink opens the dialog with the id. Without JavaScript it links to fallbackURL, a route that renders the dialog with Fallback.
+func DialogLink(dialogID string, fallbackURL string, children ...HTMLView) DialogLinkView {
+	return DialogLinkView{dialogID: dialogID, fallbackURL: fallbackURL, children: children}
+}
+
+// Use the provided enhancers on the button or link
+func (link DialogLinkView) Use(enhancers ...HTMLEnhancer) DialogLinkView {
+	link.enhancers = append(link.enhancers[:len(link.enhancers):len(link.enhancers)], enhancers...)
+	return link
+}
+
+func (link DialogLinkView) apply(node *html.Node, ctx *buildContext) {
+	view := Link(link.fallbackURL, link.children...)
+	if scriptEnhanced {
+		view = Button(link.children...).Use(OpensDialog(link.dialogID))
+	}
+	view.Use(link.enhancers...).apply(node, ctx)
+}
@@ -0,0 +1,65 @@
+package dovetail
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestDialog(t *testing.T) {
+	t.Run("Rendering Dialog", func(t *testing.T) {
+		s := subjectAsString(
+			Dialog("confirm-delete", Text("Delete photo?"), P(Text("This can’t be undone."))).
+				Actions(SubmitButton(Text("Delete"), CustomAttr("value", "delete"))).
+				CloseLabel("Cancel"),
+		)
+
+		t.Run(`it renders <dialog> labelled by its heading with a method="dialog" form`, func(t *testing.T) {
+			assert.Equal(t, s, `<dialog id="confirm-delete" aria-labelledby="confirm-delete-title">`+
+				`<h2 id="confirm-delete-title">Delete photo?</h2><p>This can’t be undone.</p>`+
+				`<form method="dialog"><button value="delete" type="submit">Delete</button><button value="close" type="submit">Cancel</button></form>`+
+				`</dialog>`)
+		})
+	})
+
+	t.Run("Rendering Dialog for its fallback route", func(t *testing.T) {
+		s := subjectAsString(
+			Dialog("confirm-delete", Text("Delete photo?")).
+				HeadingLevel(1).
+				Actions(SubmitButton(Text("Delete"))).
+				Fallback("/photos/1"),
+		)
+
+		t.Run(`it renders already open, posting the actions and linking to close`, func(t *testing.T) {
+			assert.Equal(t, s, `<dialog id="confirm-delete" aria-labelledby="confirm-delete-title" open="">`+
+				`<h1 id="confirm-delete-title">Delete photo?</h1>`+
+				`<form method="post"><button type="submit">Delete</button><a href="/photos/1">Close</a></form>`+
+				`</dialog>`)
+		})
+	})
+
+	t.Run("Rendering a Button that opens a dialog", func(t *testing.T) {
+		s := subjectAsString(Button(Text("Delete")).Use(OpensDialog("confirm-delete")))
+
+		t.Run(`it adds the dialog’s id and aria-haspopup`, func(t *testing.T) {
+			assert.Equal(t, s, `<button type="button" aria-haspopup="dialog" data-opens-dialog="confirm-delete">Delete</button>`)
+		})
+	})
+
+	t.Run("Rendering DialogLink without JavaScript", func(t *testing.T) {
+		s := subjectAsString(DialogLink("confirm-delete", "/photos/1/delete", Text("Delete")))
+
+		t.Run(`it links to the fallback route`, func(t *testing.T) {
+			assert.Equal(t, s, `<a href="/photos/1/delete">Delete</a>`)
+		})
+	})
+
+	t.Run("Rendering DialogLink enhanced by WebAssembly", func(t *testing.T) {
+		link := DialogLink("confirm-delete", "/photos/1/delete", Text("Delete")).Use(Class("danger"))
+		s := renderScriptEnhanced(func() HTMLView { return link })
+
+		t.Run(`it renders a button that opens the dialog`, func(t *testing.T) {
+			assert.Equal(t, s, `<button type="button" aria-haspopup="dialog" data-opens-dialog="confirm-delete" class="danger">Delete</button>`)
+		})
+	})
+}
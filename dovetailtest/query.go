@@ -0,0 +1,312 @@
+// Package dovetailtest builds views and queries them the way users and assistive technology see them,
+// inspired by Testing Library.
+//
+//	screen := dovetailtest.Render(view)
+//	nav := screen.Get(t, dovetailtest.ByRole("navigation", dovetailtest.Name("Primary")))
+//	link := nav.Get(t, dovetailtest.ByRole("link", dovetailtest.Name("Pricing")))
+//	assert.Equal(t, link.Attr("aria-current"), "page")
+package dovetailtest
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/RoyalIcing/dovetail"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// TestingT is the subset of testing.TB used to fail a test when a query does not match
+type TestingT interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+}
+
+// Node is an element within a built view, which can be inspected or queried further
+type Node struct {
+	node *html.Node
+}
+
+// Render builds view so it can be queried
+func Render(view dovetail.HTMLView) *Node {
+	tree := dovetail.Build(view)
+	fillAtoms(tree)
+	return &Node{node: tree}
+}
+
+// fillAtoms sets DataAtom for elements made with just a tag name, so roles can be looked up
+func fillAtoms(tree *html.Node) {
+	if tree.Type == html.ElementNode && tree.DataAtom == 0 {
+		tree.DataAtom = atom.Lookup([]byte(tree.Data))
+	}
+	for child := tree.FirstChild; child != nil; child = child.NextSibling {
+		fillAtoms(child)
+	}
+}
+
+// HTMLNode returns the underlying html.Node
+func (n *Node) HTMLNode() *html.Node {
+	return n.node
+}
+
+// Tag returns the element’s tag name, such as "a" or "button"
+func (n *Node) Tag() string {
+	return n.node.Data
+}
+
+// Attr returns the value of the attribute key, or "" if it is not present
+func (n *Node) Attr(key string) string {
+	value, _ := attr(n.node, key)
+	return value
+}
+
+// HasAttr returns whether the attribute key is present
+func (n *Node) HasAttr(key string) bool {
+	_, ok := attr(n.node, key)
+	return ok
+}
+
+// Text returns the text content, with whitespace collapsed
+func (n *Node) Text() string {
+	return normalizeSpace(plainText(n.node))
+}
+
+// Role returns the explicit or implicit ARIA role, such as "navigation" for <nav>
+func (n *Node) Role() string {
+	return ariaRole(n.node)
+}
+
+// Name returns the accessible name, such as the label text of a form field or the text of a link
+func (n *Node) Name() string {
+	return accessibleName(n.node)
+}
+
+// Children returns the child elements
+func (n *Node) Children() []*Node {
+	var children []*Node
+	for child := n.node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode {
+			children = append(children, &Node{node: child})
+		}
+	}
+	return children
+}
+
+// HTML renders the element and its descendants
+func (n *Node) HTML() string {
+	b := new(bytes.Buffer)
+	html.Render(b, n.node)
+	return b.String()
+}
+
+func (n *Node) String() string {
+	return n.HTML()
+}
+
+// Query matches elements within a built view
+type Query struct {
+	description string
+	matches     func(node *html.Node) bool
+}
+
+func (query Query) String() string {
+	return query.description
+}
+
+// QueryAll returns every element matching query, including n itself, in document order
+func (n *Node) QueryAll(query Query) []*Node {
+	var found []*Node
+	walk(n.node, func(node *html.Node) bool {
+		if query.matches(node) {
+			found = append(found, &Node{node: node})
+		}
+		return true
+	})
+	return found
+}
+
+// Query returns the single element matching query, or nil if there are none.
+// It returns an error if more than one element matches.
+func (n *Node) Query(query Query) (*Node, error) {
+	found := n.QueryAll(query)
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("found %d elements %v, expected one", len(found), query)
+	}
+}
+
+// Get returns the single element matching query, failing the test if there is not exactly one
+func (n *Node) Get(t TestingT, query Query) *Node {
+	t.Helper()
+
+	found := n.QueryAll(query)
+	if len(found) != 1 {
+		t.Fatalf("found %d elements %v, expected one within:\n%s", len(found), query, n.HTML())
+		return nil
+	}
+	return found[0]
+}
+
+// GetAll returns every element matching query, failing the test if there are none
+func (n *Node) GetAll(t TestingT, query Query) []*Node {
+	t.Helper()
+
+	found := n.QueryAll(query)
+	if len(found) == 0 {
+		t.Fatalf("found no elements %v within:\n%s", query, n.HTML())
+	}
+	return found
+}
+
+type roleQuery struct {
+	role   string
+	name   *string
+	level  int
+	hidden bool
+}
+
+// RoleOption narrows a ByRole query
+type RoleOption func(query roleQuery) roleQuery
+
+// Name only matches elements with the exact accessible name, ignoring surrounding whitespace
+func Name(name string) RoleOption {
+	return func(query roleQuery) roleQuery {
+		name = normalizeSpace(name)
+		query.name = &name
+		return query
+	}
+}
+
+// Level only matches headings of the level, such as 1 for <h1>
+func Level(level int) RoleOption {
+	return func(query roleQuery) roleQuery {
+		query.level = level
+		return query
+	}
+}
+
+// IncludeHidden also matches elements removed from the accessibility tree, such as with aria-hidden
+func IncludeHidden(query roleQuery) roleQuery {
+	query.hidden = true
+	return query
+}
+
+func headingLevel(node *html.Node) int {
+	if value, ok := attr(node, "aria-level"); ok {
+		level, _ := strconv.Atoi(value)
+		return level
+	}
+	switch node.DataAtom {
+	case atom.H1:
+		return 1
+	case atom.H2:
+		return 2
+	case atom.H3:
+		return 3
+	case atom.H4:
+		return 4
+	case atom.H5:
+		return 5
+	case atom.H6:
+		return 6
+	}
+	return 0
+}
+
+// ByRole matches elements with the ARIA role, either explicit or implicit such as "navigation" for <nav>
+func ByRole(role string, options ...RoleOption) Query {
+	query := roleQuery{role: role}
+	for _, option := range options {
+		query = option(query)
+	}
+
+	description := fmt.Sprintf("with role %q", role)
+	if query.name != nil {
+		description += fmt.Sprintf(" and name %q", *query.name)
+	}
+	if query.level > 0 {
+		description += fmt.Sprintf(" and level %d", query.level)
+	}
+
+	return Query{
+		description: description,
+		matches: func(node *html.Node) bool {
+			if ariaRole(node) != query.role {
+				return false
+			}
+			if !query.hidden && isHidden(node) {
+				return false
+			}
+			if query.level > 0 && headingLevel(node) != query.level {
+				return false
+			}
+			if query.name != nil && accessibleName(node) != *query.name {
+				return false
+			}
+			return true
+		},
+	}
+}
+
+// ByLabelText matches form controls labelled with text, such as the input within FieldLabelled
+func ByLabelText(text string) Query {
+	text = normalizeSpace(text)
+	return Query{
+		description: fmt.Sprintf("labelled %q", text),
+		matches: func(node *html.Node) bool {
+			if label, ok := attr(node, "aria-label"); ok && normalizeSpace(label) == text {
+				return true
+			}
+			if _, ok := attr(node, "aria-labelledby"); ok && accessibleName(node) == text {
+				return true
+			}
+			if !isLabelable(node) {
+				return false
+			}
+			for _, label := range labelsFor(node) {
+				if normalizeSpace(textContent(label, node)) == text {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// ByText matches the innermost elements whose text content is text, ignoring surrounding whitespace
+func ByText(text string) Query {
+	text = normalizeSpace(text)
+	return Query{
+		description: fmt.Sprintf("with text %q", text),
+		matches: func(node *html.Node) bool {
+			if node.DataAtom == atom.Script || node.DataAtom == atom.Style {
+				return false
+			}
+			if normalizeSpace(plainText(node)) != text {
+				return false
+			}
+			for child := node.FirstChild; child != nil; child = child.NextSibling {
+				if child.Type == html.ElementNode && normalizeSpace(plainText(child)) == text {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+// ByTestID matches elements with the attribute data-testid, as added by DataAttr("testid", id)
+func ByTestID(id string) Query {
+	return Query{
+		description: fmt.Sprintf("with test id %q", id),
+		matches: func(node *html.Node) bool {
+			value, ok := attr(node, "data-testid")
+			return ok && value == id
+		},
+	}
+}
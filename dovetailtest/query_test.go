@@ -0,0 +1,115 @@
+package dovetailtest_test
+
+import (
+	"testing"
+
+	. "github.com/RoyalIcing/dovetail"
+	"github.com/RoyalIcing/dovetail/dovetailtest"
+	"gotest.tools/assert"
+)
+
+func TestQueries(t *testing.T) {
+	screen := dovetailtest.Render(Div(
+		Header(
+			Nav(
+				AriaLabel("Primary"),
+				List(
+					Link("/", Text("Home")),
+					Link("/pricing", Text("Pricing"), AriaCurrentPage),
+				),
+			),
+		),
+		Main(
+			Article(
+				Header(H(1, Text("Welcome"))),
+				P(Text("Render HTML using "), TextWith("components", DataAttr("testid", "highlight"))),
+				Img("/logo.png", "Dovetail logo"),
+				Div(AriaHidden(), Button(Text("Hidden"))),
+			),
+			FormTo("/newsletter").With(
+				FieldLabelled("Email", Textbox("email")),
+				FieldLabelled("Message", Textbox("message").Rows(3).DefaultValue("Hi")),
+				SubmitButton(Text("Sign up")),
+			),
+		),
+	))
+
+	t.Run(`ByRole finds implicit landmark roles`, func(t *testing.T) {
+		nav := screen.Get(t, dovetailtest.ByRole("navigation"))
+		assert.Equal(t, nav.Tag(), "nav")
+		assert.Equal(t, nav.Name(), "Primary")
+
+		main := screen.Get(t, dovetailtest.ByRole("main"))
+		assert.Equal(t, main.Tag(), "main")
+	})
+
+	t.Run(`ByRole only treats the top-level header as a banner`, func(t *testing.T) {
+		banners := screen.QueryAll(dovetailtest.ByRole("banner"))
+		assert.Equal(t, len(banners), 1)
+		assert.Equal(t, banners[0].Children()[0].Tag(), "nav")
+	})
+
+	t.Run(`ByRole with Name matches the accessible name`, func(t *testing.T) {
+		link := screen.Get(t, dovetailtest.ByRole("link", dovetailtest.Name("Pricing")))
+		assert.Equal(t, link.Attr("href"), "/pricing")
+		assert.Equal(t, link.Attr("aria-current"), "page")
+
+		img := screen.Get(t, dovetailtest.ByRole("img", dovetailtest.Name("Dovetail logo")))
+		assert.Equal(t, img.Attr("src"), "/logo.png")
+	})
+
+	t.Run(`ByRole with Level matches headings`, func(t *testing.T) {
+		heading := screen.Get(t, dovetailtest.ByRole("heading", dovetailtest.Level(1)))
+		assert.Equal(t, heading.Text(), "Welcome")
+
+		none, err := screen.Query(dovetailtest.ByRole("heading", dovetailtest.Level(2)))
+		assert.NilError(t, err)
+		assert.Assert(t, none == nil)
+	})
+
+	t.Run(`ByRole skips hidden elements unless included`, func(t *testing.T) {
+		buttons := screen.QueryAll(dovetailtest.ByRole("button"))
+		assert.Equal(t, len(buttons), 1)
+		assert.Equal(t, buttons[0].Name(), "Sign up")
+
+		all := screen.QueryAll(dovetailtest.ByRole("button", dovetailtest.IncludeHidden))
+		assert.Equal(t, len(all), 2)
+	})
+
+	t.Run(`queries can be scoped within a node`, func(t *testing.T) {
+		nav := screen.Get(t, dovetailtest.ByRole("navigation", dovetailtest.Name("Primary")))
+		links := nav.GetAll(t, dovetailtest.ByRole("link"))
+		assert.Equal(t, len(links), 2)
+		assert.Equal(t, links[0].Name(), "Home")
+	})
+
+	t.Run(`ByLabelText finds the control of FieldLabelled`, func(t *testing.T) {
+		email := screen.Get(t, dovetailtest.ByLabelText("Email"))
+		assert.Equal(t, email.Tag(), "input")
+		assert.Equal(t, email.Attr("name"), "email")
+		assert.Equal(t, email.Role(), "textbox")
+
+		message := screen.Get(t, dovetailtest.ByLabelText("Message"))
+		assert.Equal(t, message.Tag(), "textarea")
+		assert.Equal(t, message.Name(), "Message")
+	})
+
+	t.Run(`ByText finds the innermost element with the text`, func(t *testing.T) {
+		welcome := screen.Get(t, dovetailtest.ByText("Welcome"))
+		assert.Equal(t, welcome.Tag(), "h1")
+
+		paragraph := screen.Get(t, dovetailtest.ByText("Render HTML using components"))
+		assert.Equal(t, paragraph.Tag(), "p")
+	})
+
+	t.Run(`ByTestID finds data-testid`, func(t *testing.T) {
+		highlight := screen.Get(t, dovetailtest.ByTestID("highlight"))
+		assert.Equal(t, highlight.Text(), "components")
+		assert.Equal(t, highlight.HTML(), `<span data-testid="highlight">components</span>`)
+	})
+
+	t.Run(`Query returns an error when more than one element matches`, func(t *testing.T) {
+		_, err := screen.Query(dovetailtest.ByRole("link"))
+		assert.Error(t, err, `found 2 elements with role "link", expected one`)
+	})
+}
@@ -0,0 +1,430 @@
+package dovetailtest
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func attr(node *html.Node, key string) (string, bool) {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+func hasAncestor(node *html.Node, atoms ...atom.Atom) bool {
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if parent.Type != html.ElementNode {
+			continue
+		}
+		for _, a := range atoms {
+			if parent.DataAtom == a {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ariaRole returns the explicit role attribute of node, or its implicit role from the ARIA in HTML specification
+func ariaRole(node *html.Node) string {
+	if node.Type != html.ElementNode {
+		return ""
+	}
+
+	if explicit, ok := attr(node, "role"); ok {
+		if fields := strings.Fields(explicit); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+
+	switch node.DataAtom {
+	case atom.A, atom.Area:
+		if _, ok := attr(node, "href"); ok {
+			return "link"
+		}
+	case atom.Article:
+		return "article"
+	case atom.Aside:
+		return "complementary"
+	case atom.Blockquote:
+		return "blockquote"
+	case atom.Button:
+		return "button"
+	case atom.Caption:
+		return "caption"
+	case atom.Code:
+		return "code"
+	case atom.Dd:
+		return "definition"
+	case atom.Del:
+		return "deletion"
+	case atom.Details, atom.Fieldset, atom.Optgroup:
+		return "group"
+	case atom.Dialog:
+		return "dialog"
+	case atom.Dt:
+		return "term"
+	case atom.Em:
+		return "emphasis"
+	case atom.Figure:
+		return "figure"
+	case atom.Footer:
+		if !hasAncestor(node, atom.Article, atom.Aside, atom.Main, atom.Nav, atom.Section) {
+			return "contentinfo"
+		}
+	case atom.Form:
+		return "form"
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		return "heading"
+	case atom.Header:
+		if !hasAncestor(node, atom.Article, atom.Aside, atom.Main, atom.Nav, atom.Section) {
+			return "banner"
+		}
+	case atom.Hr:
+		return "separator"
+	case atom.Html:
+		return "document"
+	case atom.Img:
+		if alt, ok := attr(node, "alt"); ok && alt == "" {
+			return "presentation"
+		}
+		return "img"
+	case atom.Input:
+		return inputRole(node)
+	case atom.Ins:
+		return "insertion"
+	case atom.Li:
+		return "listitem"
+	case atom.Main:
+		return "main"
+	case atom.Menu, atom.Ol, atom.Ul:
+		return "list"
+	case atom.Meter:
+		return "meter"
+	case atom.Nav:
+		return "navigation"
+	case atom.Option:
+		return "option"
+	case atom.Output:
+		return "status"
+	case atom.P:
+		return "paragraph"
+	case atom.Progress:
+		return "progressbar"
+	case atom.Section:
+		_, labelled := attr(node, "aria-labelledby")
+		_, label := attr(node, "aria-label")
+		if labelled || label {
+			return "region"
+		}
+	case atom.Select:
+		_, multiple := attr(node, "multiple")
+		size, _ := attr(node, "size")
+		if multiple || (size != "" && size != "0" && size != "1") {
+			return "listbox"
+		}
+		return "combobox"
+	case atom.Strong:
+		return "strong"
+	case atom.Sub:
+		return "subscript"
+	case atom.Sup:
+		return "superscript"
+	case atom.Table:
+		return "table"
+	case atom.Tbody, atom.Tfoot, atom.Thead:
+		return "rowgroup"
+	case atom.Td:
+		return "cell"
+	case atom.Textarea:
+		return "textbox"
+	case atom.Th:
+		if scope, _ := attr(node, "scope"); scope == "row" || scope == "rowgroup" {
+			return "rowheader"
+		}
+		return "columnheader"
+	case atom.Time:
+		return "time"
+	case atom.Tr:
+		return "row"
+	}
+
+	return ""
+}
+
+func inputRole(node *html.Node) string {
+	inputType, _ := attr(node, "type")
+	_, hasList := attr(node, "list")
+
+	switch strings.ToLower(inputType) {
+	case "", "text", "email", "tel", "url":
+		if hasList {
+			return "combobox"
+		}
+		return "textbox"
+	case "search":
+		if hasList {
+			return "combobox"
+		}
+		return "searchbox"
+	case "button", "image", "reset", "submit":
+		return "button"
+	case "checkbox":
+		return "checkbox"
+	case "number":
+		return "spinbutton"
+	case "radio":
+		return "radio"
+	case "range":
+		return "slider"
+	}
+	return ""
+}
+
+// namedFromContent are the roles whose accessible name can come from their text content
+var namedFromContent = map[string]bool{
+	"button":           true,
+	"cell":             true,
+	"checkbox":         true,
+	"columnheader":     true,
+	"gridcell":         true,
+	"heading":          true,
+	"link":             true,
+	"menuitem":         true,
+	"menuitemcheckbox": true,
+	"menuitemradio":    true,
+	"option":           true,
+	"radio":            true,
+	"row":              true,
+	"rowheader":        true,
+	"switch":           true,
+	"tab":              true,
+	"term":             true,
+	"tooltip":          true,
+	"treeitem":         true,
+}
+
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func isLabelable(node *html.Node) bool {
+	if node.Type != html.ElementNode {
+		return false
+	}
+	switch node.DataAtom {
+	case atom.Button, atom.Meter, atom.Output, atom.Progress, atom.Select, atom.Textarea:
+		return true
+	case atom.Input:
+		inputType, _ := attr(node, "type")
+		return inputType != "hidden"
+	}
+	return false
+}
+
+func root(node *html.Node) *html.Node {
+	for node.Parent != nil {
+		node = node.Parent
+	}
+	return node
+}
+
+func findByID(tree *html.Node, id string) *html.Node {
+	var found *html.Node
+	walk(tree, func(node *html.Node) bool {
+		if value, ok := attr(node, "id"); ok && value == id {
+			found = node
+		}
+		return found == nil
+	})
+	return found
+}
+
+// walk calls visit for each element in tree depth-first, including tree itself, until visit returns false
+func walk(tree *html.Node, visit func(node *html.Node) bool) bool {
+	if tree.Type == html.ElementNode && !visit(tree) {
+		return false
+	}
+	for child := tree.FirstChild; child != nil; child = child.NextSibling {
+		if !walk(child, visit) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHidden(node *html.Node) bool {
+	for ; node != nil; node = node.Parent {
+		if hidden, _ := attr(node, "aria-hidden"); hidden == "true" {
+			return true
+		}
+		if _, ok := attr(node, "hidden"); ok && node.Type == html.ElementNode {
+			return true
+		}
+	}
+	return false
+}
+
+// textContent concatenates the text within node, including the alt text of images, but skipping hidden elements and skip
+func textContent(node *html.Node, skip *html.Node) string {
+	if node == skip {
+		return ""
+	}
+
+	switch node.Type {
+	case html.TextNode:
+		return node.Data
+	case html.ElementNode:
+		if hidden, _ := attr(node, "aria-hidden"); hidden == "true" {
+			return ""
+		}
+		switch node.DataAtom {
+		case atom.Script, atom.Style, atom.Template:
+			return ""
+		case atom.Img:
+			alt, _ := attr(node, "alt")
+			return alt
+		}
+		if label, ok := attr(node, "aria-label"); ok && label != "" {
+			return label
+		}
+	}
+
+	var b strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(textContent(child, skip))
+		if child.Type == html.ElementNode && !isInline(child) {
+			b.WriteString(" ")
+		}
+	}
+	return b.String()
+}
+
+// plainText concatenates the text nodes within node, skipping scripts and styles
+func plainText(node *html.Node) string {
+	switch node.Type {
+	case html.TextNode:
+		return node.Data
+	case html.ElementNode:
+		switch node.DataAtom {
+		case atom.Script, atom.Style, atom.Template:
+			return ""
+		}
+	}
+
+	var b strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(plainText(child))
+	}
+	return b.String()
+}
+
+func isInline(node *html.Node) bool {
+	switch node.DataAtom {
+	case atom.A, atom.Abbr, atom.B, atom.Bdi, atom.Bdo, atom.Cite, atom.Code, atom.Data, atom.Del, atom.Dfn, atom.Em, atom.I, atom.Img, atom.Ins, atom.Kbd, atom.Mark, atom.Q, atom.S, atom.Samp, atom.Small, atom.Span, atom.Strong, atom.Sub, atom.Sup, atom.Time, atom.U, atom.Var:
+		return true
+	}
+	return false
+}
+
+// labelsFor returns the <label> elements associated with a form control
+func labelsFor(control *html.Node) []*html.Node {
+	var labels []*html.Node
+	for parent := control.Parent; parent != nil; parent = parent.Parent {
+		if parent.DataAtom == atom.Label {
+			labels = append(labels, parent)
+			break
+		}
+	}
+
+	if id, ok := attr(control, "id"); ok && id != "" {
+		walk(root(control), func(node *html.Node) bool {
+			if node.DataAtom == atom.Label {
+				if forID, _ := attr(node, "for"); forID == id {
+					labels = append(labels, node)
+				}
+			}
+			return true
+		})
+	}
+	return labels
+}
+
+// accessibleName computes a simplified version of the accessible name from the Accessible Name and Description Computation specification
+func accessibleName(node *html.Node) string {
+	if labelledBy, ok := attr(node, "aria-labelledby"); ok {
+		var parts []string
+		for _, id := range strings.Fields(labelledBy) {
+			if labelNode := findByID(root(node), id); labelNode != nil {
+				parts = append(parts, textContent(labelNode, nil))
+			}
+		}
+		if name := normalizeSpace(strings.Join(parts, " ")); name != "" {
+			return name
+		}
+	}
+
+	if label, ok := attr(node, "aria-label"); ok {
+		if name := normalizeSpace(label); name != "" {
+			return name
+		}
+	}
+
+	if isLabelable(node) {
+		var parts []string
+		for _, label := range labelsFor(node) {
+			parts = append(parts, textContent(label, node))
+		}
+		if name := normalizeSpace(strings.Join(parts, " ")); name != "" {
+			return name
+		}
+	}
+
+	switch node.DataAtom {
+	case atom.Img, atom.Area:
+		if alt, ok := attr(node, "alt"); ok {
+			return normalizeSpace(alt)
+		}
+	case atom.Input:
+		inputType, _ := attr(node, "type")
+		switch inputType {
+		case "button", "submit", "reset":
+			if value, ok := attr(node, "value"); ok {
+				return normalizeSpace(value)
+			}
+			if inputType == "submit" {
+				return "Submit"
+			}
+			if inputType == "reset" {
+				return "Reset"
+			}
+		case "image":
+			if alt, ok := attr(node, "alt"); ok {
+				return normalizeSpace(alt)
+			}
+		}
+	case atom.Table, atom.Figure, atom.Fieldset:
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.DataAtom == atom.Caption || child.DataAtom == atom.Figcaption || child.DataAtom == atom.Legend {
+				return normalizeSpace(textContent(child, nil))
+			}
+		}
+	}
+
+	if namedFromContent[ariaRole(node)] {
+		if name := normalizeSpace(textContent(node, nil)); name != "" {
+			return name
+		}
+	}
+
+	if title, ok := attr(node, "title"); ok {
+		return normalizeSpace(title)
+	}
+
+	return ""
+}
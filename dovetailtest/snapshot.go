@@ -0,0 +1,86 @@
+package dovetailtest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/RoyalIcing/dovetail"
+	"gotest.tools/golden"
+)
+
+// updateFlag writes the golden files with go test -update
+const updateFlag = "update"
+
+// goldenUpdateFlag is gotest.tools/golden’s own flag for writing golden files, which -update also sets
+const goldenUpdateFlag = "test.update-golden"
+
+func init() {
+	// Share -update if a package imported earlier already defines it
+	if flag.Lookup(updateFlag) == nil {
+		flag.Bool(updateFlag, false, "write the golden files of snapshots")
+	}
+}
+
+func updatingGoldenFiles() bool {
+	for _, name := range []string{updateFlag, goldenUpdateFlag} {
+		if f := flag.Lookup(name); f != nil && f.Value.String() == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// failureMessenger is the gotest.tools result that describes a failed comparison
+type failureMessenger interface {
+	FailureMessage() string
+}
+
+// AssertSnapshot renders view with Snapshot and compares it to the golden file testdata/<name>.golden.
+// Run the tests with -update to write the golden files. The -update flag is defined by this package,
+// so tests using AssertSnapshot should not define their own.
+func AssertSnapshot(t TestingT, view dovetail.HTMLView, name string) {
+	t.Helper()
+
+	actual, err := snapshot(view)
+	if err != nil {
+		t.Fatalf("could not render snapshot: %v", err)
+	}
+	filename := name + ".golden"
+	path := golden.Path(filename)
+
+	if updatingGoldenFiles() {
+		if err := flag.Set(goldenUpdateFlag, "true"); err != nil {
+			t.Fatalf("could not update golden files: %v", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("could not create snapshot directory: %v", err)
+		}
+	}
+
+	// The golden file is written first when updating
+	result := golden.String(actual, filename)()
+	if result.Success() {
+		return
+	}
+
+	message := "unknown failure"
+	if failure, ok := result.(failureMessenger); ok {
+		message = failure.FailureMessage()
+	}
+	t.Fatalf("snapshot %s does not match, run with -%s if the change is expected: %s", path, updateFlag, message)
+}
+
+// Snapshot renders view with dovetail.RenderIndented, so each block-level element is on its own line, indented by depth
+func Snapshot(view dovetail.HTMLView) string {
+	s, _ := snapshot(view)
+	return s
+}
+
+func snapshot(view dovetail.HTMLView) (string, error) {
+	var b strings.Builder
+	err := dovetail.RenderIndented(&b, "  ", view)
+	b.WriteString("\n")
+	return b.String(), err
+}
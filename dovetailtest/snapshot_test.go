@@ -0,0 +1,51 @@
+package dovetailtest_test
+
+import (
+	"testing"
+
+	. "github.com/RoyalIcing/dovetail"
+	"github.com/RoyalIcing/dovetail/dovetailtest"
+	"gotest.tools/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Run("Snapshot of nested elements", func(t *testing.T) {
+		s := dovetailtest.Snapshot(Nav(
+			AriaLabel("Primary"),
+			List(
+				Link("/", Text("Home")),
+				Link("/about?a=1&b=2", Text("About & Contact")),
+			),
+		))
+
+		t.Run(`it puts each block-level element on its own line, indented by depth`, func(t *testing.T) {
+			assert.Equal(t, s, `<nav aria-label="Primary">
+  <ul>
+    <li><a href="/">Home</a></li>
+    <li><a href="/about?a=1&amp;b=2">About &amp; Contact</a></li>
+  </ul>
+</nav>
+`)
+		})
+	})
+
+	t.Run("AssertSnapshot of the readme example", func(t *testing.T) {
+		dovetailtest.AssertSnapshot(t, Div(
+			Header(
+				Nav(
+					AriaLabel("Primary"),
+					List(
+						Link("/", Text("Home")),
+						Link("/pricing", Text("Pricing"), AriaCurrentPage),
+					),
+				),
+			),
+			Main(
+				FormTo("/newsletter").With(
+					FieldLabelled("Email", Textbox("email")),
+					SubmitButton(Text("Sign up for the newsletter")),
+				),
+			),
+		), "readme")
+	})
+}
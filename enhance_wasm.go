@@ -0,0 +1,110 @@
+package dovetail
+
+import (
+	"syscall/js"
+)
+
+func init() {
+	scriptEnhanced = true
+}
+
+// listenForEnhancedEvents handles clicks and keys for components rendered by the WebAssembly build, such as Accordion, OpensDialog and Tabs.
+// Listeners are added to the document, so they keep working when the body is rendered again.
+func listenForEnhancedEvents() {
+	document := js.Global().Get("document")
+	document.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		target := args[0].Get("target")
+
+		if opener := target.Call("closest", "[data-opens-dialog]"); !opener.IsNull() {
+			dialog := document.Call("getElementById", opener.Call("getAttribute", "data-opens-dialog"))
+			if !dialog.IsNull() {
+				dialog.Call("showModal")
+			}
+			return nil
+		}
+
+		if tab := target.Call("closest", "[data-tabs] [role=tab]"); !tab.IsNull() {
+			selectTab(document, tab)
+			return nil
+		}
+
+		button := target.Call("closest", "[data-accordion] button[aria-controls]")
+		if button.IsNull() {
+			return nil
+		}
+
+		panel := document.Call("getElementById", button.Call("getAttribute", "aria-controls"))
+		if panel.IsNull() {
+			return nil
+		}
+
+		expanded := button.Call("getAttribute", "aria-expanded").String() == "true"
+		if expanded {
+			button.Call("setAttribute", "aria-expanded", "false")
+			panel.Call("setAttribute", "hidden", "")
+		} else {
+			button.Call("setAttribute", "aria-expanded", "true")
+			panel.Call("removeAttribute", "hidden")
+		}
+		return nil
+	}))
+
+	document.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		event := args[0]
+		tab := event.Get("target").Call("closest", "[data-tabs] [role=tab]")
+		if tab.IsNull() {
+			return nil
+		}
+
+		tabs := tab.Get("parentElement").Call("querySelectorAll", "[role=tab]")
+		count := tabs.Get("length").Int()
+		index := 0
+		for i := 0; i < count; i++ {
+			if tabs.Index(i).Equal(tab) {
+				index = i
+			}
+		}
+
+		switch event.Get("key").String() {
+		case "ArrowLeft":
+			index = (index + count - 1) % count
+		case "ArrowRight":
+			index = (index + 1) % count
+		case "Home":
+			index = 0
+		case "End":
+			index = count - 1
+		default:
+			return nil
+		}
+
+		event.Call("preventDefault")
+		next := tabs.Index(index)
+		selectTab(document, next)
+		next.Call("focus")
+		return nil
+	}))
+}
+
+// selectTab selects the tab and shows its panel, moving the other tabs out of the tab order and hiding their panels
+func selectTab(document js.Value, selected js.Value) {
+	tabs := selected.Get("parentElement").Call("querySelectorAll", "[role=tab]")
+	for i := 0; i < tabs.Get("length").Int(); i++ {
+		tab := tabs.Index(i)
+		panel := document.Call("getElementById", tab.Call("getAttribute", "aria-controls"))
+
+		if tab.Equal(selected) {
+			tab.Call("setAttribute", "aria-selected", "true")
+			tab.Call("setAttribute", "tabindex", FocusViaTab.Value)
+			if !panel.IsNull() {
+				panel.Call("removeAttribute", "hidden")
+			}
+		} else {
+			tab.Call("setAttribute", "aria-selected", "false")
+			tab.Call("setAttribute", "tabindex", FocusViaScript.Value)
+			if !panel.IsNull() {
+				panel.Call("setAttribute", "hidden", "")
+			}
+		}
+	}
+}
@@ -13,6 +13,7 @@ type FormHTMLView struct {
 	Method      string
 	Action      string
 	encType     string
+	trusted     bool
 	elementCore HTMLElementCore
 }
 
@@ -25,6 +26,13 @@ func FormTo(action string, options ...func(form FormHTMLView) FormHTMLView) Form
 	return form
 }
 
+// FormToTrusted sets the form’s action to a trusted URL, which is rendered without checking its scheme
+func FormToTrusted(action TrustedURL, options ...func(form FormHTMLView) FormHTMLView) FormHTMLView {
+	form := FormTo(string(action), options...)
+	form.trusted = true
+	return form
+}
+
 func Multipart(form FormHTMLView) FormHTMLView {
 	form.encType = "multipart/form-data"
 	return form
@@ -42,17 +50,21 @@ func (form FormHTMLView) With(view ...HTMLView) FormHTMLView {
 	return form
 }
 
-func (form FormHTMLView) apply(node *html.Node) {
+func (form FormHTMLView) apply(node *html.Node, ctx *buildContext) {
 	node.Type = html.ElementNode
 	node.Data = "form"
 	node.DataAtom = atom.Form
-	node.Attr = []html.Attribute{{Key: "method", Val: form.Method}, {Key: "action", Val: form.Action}}
+	action := form.Action
+	if !form.trusted {
+		action = safeURL(action)
+	}
+	node.Attr = []html.Attribute{{Key: "method", Val: form.Method}, {Key: "action", Val: action}}
 
 	if form.encType != "" {
 		node.Attr = append(node.Attr, html.Attribute{Key: "enctype", Val: form.encType})
 	}
 
-	form.elementCore.applyToNode(node)
+	form.elementCore.applyToNode(node, ctx)
 }
 
 type FieldInputProps struct {
@@ -178,7 +190,7 @@ func (field FieldHTMLView) Class(className string) FieldHTMLView {
 	return field
 }
 
-func (field FieldHTMLView) apply(node *html.Node) {
+func (field FieldHTMLView) apply(node *html.Node, ctx *buildContext) {
 	inputType := field.inputProps.inputType
 	if inputType == "" {
 		inputType = "text"
@@ -217,7 +229,7 @@ func (field FieldHTMLView) apply(node *html.Node) {
 		Data:     "span",
 		DataAtom: atom.Span,
 	}
-	spanEl.AppendChild(Build(field.labelInnerView))
+	spanEl.AppendChild(ctx.build(field.labelInnerView))
 
 	node.Type = html.ElementNode
 	node.Data = "label"
@@ -225,8 +237,8 @@ func (field FieldHTMLView) apply(node *html.Node) {
 
 	node.AppendChild(spanEl)
 
-	field.inputProps.core.applyToNode(inputEl)
+	field.inputProps.core.applyToNode(inputEl, ctx)
 	node.AppendChild(inputEl)
 
-	field.labelCore.applyToNode(node)
+	field.labelCore.applyToNode(node, ctx)
 }
@@ -0,0 +1,290 @@
+package dovetail
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// formatNumber writes n with the locale’s separators, rounded to fraction digits, or up to 3 digits if fraction is negative
+func formatNumber(formats localeFormats, n float64, fraction int) string {
+	var s string
+	if fraction < 0 {
+		s = strconv.FormatFloat(math.Abs(n), 'f', 3, 64)
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	} else {
+		s = strconv.FormatFloat(math.Abs(n), 'f', fraction, 64)
+	}
+
+	integer, decimals := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, decimals = s[:i], s[i+1:]
+	}
+
+	var b strings.Builder
+	if n < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteString("-")
+	}
+	if len(integer) >= 4 && len(integer)-3 >= formats.minGrouping {
+		first := len(integer) % 3
+		if first == 0 {
+			first = 3
+		}
+		b.WriteString(integer[:first])
+		for i := first; i < len(integer); i += 3 {
+			b.WriteString(formats.group)
+			b.WriteString(integer[i : i+3])
+		}
+	} else {
+		b.WriteString(integer)
+	}
+	if decimals != "" {
+		b.WriteString(formats.decimal)
+		b.WriteString(decimals)
+	}
+	return b.String()
+}
+
+// NumberView renders a number or amount of money in the request’s locale
+type NumberView struct {
+	value    float64
+	fraction int
+	currency string
+}
+
+// FormatNumber renders n with the separators of the request’s locale, such as 1,234.5 in English or 1.234,5 in German.
+// Up to 3 fraction digits are shown unless set with Fraction.
+func FormatNumber(n float64) NumberView {
+	return NumberView{value: n, fraction: -1}
+}
+
+// FormatCurrency renders an amount of money in the currency with ISO 4217 code, such as "USD" or "EUR",
+// placing the symbol where the request’s locale does: $1,234.50 in English or 1.234,50 € in German
+func FormatCurrency(amount float64, code string) NumberView {
+	fraction, ok := currencyDigits[code]
+	if !ok {
+		fraction = 2
+	}
+	return NumberView{value: amount, fraction: fraction, currency: code}
+}
+
+// Fraction sets the number of digits shown after the decimal separator
+func (view NumberView) Fraction(digits int) NumberView {
+	view.fraction = digits
+	return view
+}
+
+// format returns the number as text in the locale
+func (view NumberView) format(locale string) string {
+	formats := formatsFor(locale)
+	if view.currency == "" {
+		return formatNumber(formats, view.value, view.fraction)
+	}
+
+	symbol, ok := currencySymbols[view.currency]
+	if !ok {
+		symbol = view.currency
+	}
+	number := formatNumber(formats, math.Abs(view.value), view.fraction)
+	pattern := formats.currencyPattern
+	if !ok && strings.Contains(pattern, "¤#") {
+		// Codes are separated from the number by a no-break space, as in CHF 10.00
+		pattern = strings.Replace(pattern, "¤#", "¤\u00a0#", 1)
+	}
+
+	s := strings.NewReplacer("¤", symbol, "#", number).Replace(pattern)
+	if view.value < 0 && number != formatNumber(formats, 0, view.fraction) {
+		s = "-" + s
+	}
+	return s
+}
+
+func (view NumberView) apply(node *html.Node, ctx *buildContext) {
+	node.Type = html.TextNode
+	node.Data = view.format(Locale(ctx.context()))
+}
+
+// DateStyle is how much detail a date is written with
+type DateStyle int
+
+// Date styles from the Unicode CLDR, from 1/2/06 to Monday, January 2, 2006 in English
+const (
+	DateShort DateStyle = iota
+	DateMedium
+	DateLong
+	DateFull
+)
+
+// formatDate writes t using a CLDR pattern, where d, M, y and E are the day, month, year and weekday,
+// and text within single quotes is literal
+func formatDate(formats localeFormats, t time.Time, pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+
+		if c == '\'' {
+			end := strings.IndexByte(pattern[i+1:], '\'')
+			if end < 0 {
+				end = len(pattern) - i - 1
+			}
+			b.WriteString(pattern[i+1 : i+1+end])
+			i += end + 2
+			continue
+		}
+
+		if !strings.ContainsRune("dMyE", rune(c)) {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+
+		count := 1
+		for i+count < len(pattern) && pattern[i+count] == c {
+			count++
+		}
+		i += count
+
+		switch {
+		case c == 'd' && count == 1:
+			b.WriteString(strconv.Itoa(t.Day()))
+		case c == 'd':
+			b.WriteString(t.Format("02"))
+		case c == 'M' && count == 1:
+			b.WriteString(strconv.Itoa(int(t.Month())))
+		case c == 'M' && count == 2:
+			b.WriteString(t.Format("01"))
+		case c == 'M' && count == 3:
+			b.WriteString(formats.shortMonths[t.Month()-1])
+		case c == 'M':
+			b.WriteString(formats.months[t.Month()-1])
+		case c == 'y' && count == 2:
+			b.WriteString(t.Format("06"))
+		case c == 'y':
+			b.WriteString(strconv.Itoa(t.Year()))
+		case c == 'E':
+			b.WriteString(formats.weekdays[t.Weekday()])
+		}
+	}
+	return b.String()
+}
+
+// DateView renders a date in the request’s locale within <time datetime>
+type DateView struct {
+	t         time.Time
+	style     DateStyle
+	enhancers []HTMLEnhancer
+}
+
+// FormatDate renders the date of t in the request’s locale, in the medium style such as Jan 2, 2006 in English.
+// It is wrapped in <time datetime="2006-01-02"> so the date can be read by machines.
+func FormatDate(t time.Time) DateView {
+	return DateView{t: t, style: DateMedium}
+}
+
+// Style sets how much detail the date is written with, such as DateShort or DateFull.
+// Any other value is written as DateMedium, and returned as an error after rendering.
+func (view DateView) Style(style DateStyle) DateView {
+	view.style = style
+	return view
+}
+
+// Use adds enhancers to the <time> element
+func (view DateView) Use(enhancers ...HTMLEnhancer) DateView {
+	view.enhancers = append(view.enhancers[:len(view.enhancers):len(view.enhancers)], enhancers...)
+	return view
+}
+
+func (view DateView) apply(node *html.Node, ctx *buildContext) {
+	formats := formatsFor(Locale(ctx.context()))
+	style := view.style
+	if style < DateShort || style > DateFull {
+		ctx.report(fmt.Errorf("dovetail: unknown DateStyle %d, using DateMedium", style))
+		style = DateMedium
+	}
+	text := formatDate(formats, view.t, formats.dateFormats[style])
+
+	HTMLElementViewOf("time", atom.Time, []HTMLView{CustomAttr("datetime", view.t.Format("2006-01-02")), Text(text)}).Use(view.enhancers...).apply(node, ctx)
+}
+
+// relativeUnits are the lengths of the units used by FormatRelativeTime, from seconds to years
+var relativeUnits = [7]time.Duration{
+	time.Second,
+	time.Minute,
+	time.Hour,
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	365 * 24 * time.Hour,
+}
+
+// RelativeTimeView renders a time relative to now in the request’s locale within <time datetime>
+type RelativeTimeView struct {
+	t         time.Time
+	now       time.Time
+	enhancers []HTMLEnhancer
+}
+
+// FormatRelativeTime renders t relative to the time of rendering, such as "3 days ago" or "in 2 hours" in English.
+// It is wrapped in <time datetime> with the exact time.
+func FormatRelativeTime(t time.Time) RelativeTimeView {
+	return RelativeTimeView{t: t}
+}
+
+// From sets the time that t is relative to, instead of the time of rendering
+func (view RelativeTimeView) From(now time.Time) RelativeTimeView {
+	view.now = now
+	return view
+}
+
+// Use adds enhancers to the <time> element
+func (view RelativeTimeView) Use(enhancers ...HTMLEnhancer) RelativeTimeView {
+	view.enhancers = append(view.enhancers[:len(view.enhancers):len(view.enhancers)], enhancers...)
+	return view
+}
+
+// format returns the relative time as text in the locale, using the largest whole unit
+func (view RelativeTimeView) format(locale string) string {
+	formats := formatsFor(locale)
+	now := view.now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	diff := view.t.Sub(now)
+	past := diff < 0
+	if past {
+		diff = -diff
+	}
+	if diff < time.Second {
+		return formats.now
+	}
+
+	unit := 0
+	for i := range relativeUnits {
+		if diff >= relativeUnits[i] {
+			unit = i
+		}
+	}
+	count := int(diff / relativeUnits[unit])
+
+	forms := formats.relativeUnits[unit]
+	text, ok := forms[PluralCategory(locale, count)]
+	if !ok {
+		text = forms[PluralOther]
+	}
+	text = strings.Replace(text, "{0}", formatNumber(formats, float64(count), 0), 1)
+
+	if past {
+		return strings.Replace(formats.relativePast, "{0}", text, 1)
+	}
+	return strings.Replace(formats.relativeFuture, "{0}", text, 1)
+}
+
+func (view RelativeTimeView) apply(node *html.Node, ctx *buildContext) {
+	Time(view.t, Text(view.format(Locale(ctx.context())))).Use(view.enhancers...).apply(node, ctx)
+}
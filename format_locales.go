@@ -0,0 +1,236 @@
+package dovetail
+
+import "strings"
+
+// localeFormats are the CLDR symbols and patterns used to format numbers, dates and relative times in a language
+type localeFormats struct {
+	decimal string
+	group   string
+	// minGrouping is the fewest integer digits to use group separators, so Spanish writes 1234 but 12.345
+	minGrouping int
+	// currencyPattern places the currency symbol ¤ and the number #, separated by a no-break space in some languages
+	currencyPattern string
+
+	// dateFormats are the short, medium, long and full patterns, using d, M, y and E like CLDR
+	dateFormats [4]string
+	months      [12]string
+	shortMonths [12]string
+	weekdays    [7]string
+
+	now            string
+	relativeFuture string
+	relativePast   string
+	// relativeUnits has the plural forms of each unit, from seconds to years
+	relativeUnits [7]message
+}
+
+func units(forms ...string) message {
+	msg := message{}
+	for i := 0; i+1 < len(forms); i += 2 {
+		msg[forms[i]] = forms[i+1]
+	}
+	return msg
+}
+
+var englishFormats = localeFormats{
+	decimal: ".", group: ",", minGrouping: 1, currencyPattern: "¤#",
+	dateFormats: [4]string{"M/d/yy", "MMM d, y", "MMMM d, y", "EEEE, MMMM d, y"},
+	months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	weekdays:    [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	now:         "now", relativeFuture: "in {0}", relativePast: "{0} ago",
+	relativeUnits: [7]message{
+		units("one", "{0} second", "other", "{0} seconds"),
+		units("one", "{0} minute", "other", "{0} minutes"),
+		units("one", "{0} hour", "other", "{0} hours"),
+		units("one", "{0} day", "other", "{0} days"),
+		units("one", "{0} week", "other", "{0} weeks"),
+		units("one", "{0} month", "other", "{0} months"),
+		units("one", "{0} year", "other", "{0} years"),
+	},
+}
+
+// britishDateFormats are used by English outside the United States
+var britishDateFormats = [4]string{"dd/MM/y", "d MMM y", "d MMMM y", "EEEE d MMMM y"}
+
+// languageFormats are keyed by base language, with English used for any other language
+var languageFormats = map[string]localeFormats{
+	"en": englishFormats,
+	"de": {
+		decimal: ",", group: ".", minGrouping: 1, currencyPattern: "#\u00a0¤",
+		dateFormats: [4]string{"dd.MM.yy", "dd.MM.y", "d. MMMM y", "EEEE, d. MMMM y"},
+		months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		weekdays:    [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		now:         "jetzt", relativeFuture: "in {0}", relativePast: "vor {0}",
+		relativeUnits: [7]message{
+			units("one", "{0} Sekunde", "other", "{0} Sekunden"),
+			units("one", "{0} Minute", "other", "{0} Minuten"),
+			units("one", "{0} Stunde", "other", "{0} Stunden"),
+			units("one", "{0} Tag", "other", "{0} Tagen"),
+			units("one", "{0} Woche", "other", "{0} Wochen"),
+			units("one", "{0} Monat", "other", "{0} Monaten"),
+			units("one", "{0} Jahr", "other", "{0} Jahren"),
+		},
+	},
+	"es": {
+		decimal: ",", group: ".", minGrouping: 2, currencyPattern: "#\u00a0¤",
+		dateFormats: [4]string{"d/M/yy", "d MMM y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		months:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		weekdays:    [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		now:         "ahora", relativeFuture: "dentro de {0}", relativePast: "hace {0}",
+		relativeUnits: [7]message{
+			units("one", "{0} segundo", "other", "{0} segundos"),
+			units("one", "{0} minuto", "other", "{0} minutos"),
+			units("one", "{0} hora", "other", "{0} horas"),
+			units("one", "{0} día", "other", "{0} días"),
+			units("one", "{0} semana", "other", "{0} semanas"),
+			units("one", "{0} mes", "other", "{0} meses"),
+			units("one", "{0} año", "other", "{0} años"),
+		},
+	},
+	"fr": {
+		decimal: ",", group: "\u202f", minGrouping: 1, currencyPattern: "#\u00a0¤",
+		dateFormats: [4]string{"dd/MM/y", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		months:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		shortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		weekdays:    [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		now:         "maintenant", relativeFuture: "dans {0}", relativePast: "il y a {0}",
+		relativeUnits: [7]message{
+			units("one", "{0} seconde", "other", "{0} secondes"),
+			units("one", "{0} minute", "other", "{0} minutes"),
+			units("one", "{0} heure", "other", "{0} heures"),
+			units("one", "{0} jour", "other", "{0} jours"),
+			units("one", "{0} semaine", "other", "{0} semaines"),
+			units("one", "{0} mois", "other", "{0} mois"),
+			units("one", "{0} an", "other", "{0} ans"),
+		},
+	},
+	"it": {
+		decimal: ",", group: ".", minGrouping: 1, currencyPattern: "#\u00a0¤",
+		dateFormats: [4]string{"dd/MM/yy", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		months:      [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		shortMonths: [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		weekdays:    [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		now:         "ora", relativeFuture: "tra {0}", relativePast: "{0} fa",
+		relativeUnits: [7]message{
+			units("one", "{0} secondo", "other", "{0} secondi"),
+			units("one", "{0} minuto", "other", "{0} minuti"),
+			units("one", "{0} ora", "other", "{0} ore"),
+			units("one", "{0} giorno", "other", "{0} giorni"),
+			units("one", "{0} settimana", "other", "{0} settimane"),
+			units("one", "{0} mese", "other", "{0} mesi"),
+			units("one", "{0} anno", "other", "{0} anni"),
+		},
+	},
+	"nl": {
+		decimal: ",", group: ".", minGrouping: 1, currencyPattern: "¤\u00a0#",
+		dateFormats: [4]string{"dd-MM-y", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		months:      [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		shortMonths: [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		weekdays:    [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		now:         "nu", relativeFuture: "over {0}", relativePast: "{0} geleden",
+		relativeUnits: [7]message{
+			units("one", "{0} seconde", "other", "{0} seconden"),
+			units("one", "{0} minuut", "other", "{0} minuten"),
+			units("one", "{0} uur", "other", "{0} uur"),
+			units("one", "{0} dag", "other", "{0} dagen"),
+			units("one", "{0} week", "other", "{0} weken"),
+			units("one", "{0} maand", "other", "{0} maanden"),
+			units("one", "{0} jaar", "other", "{0} jaar"),
+		},
+	},
+	"pt": {
+		decimal: ",", group: ".", minGrouping: 1, currencyPattern: "¤\u00a0#",
+		dateFormats: [4]string{"dd/MM/y", "d 'de' MMM 'de' y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		months:      [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		shortMonths: [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		weekdays:    [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		now:         "agora", relativeFuture: "em {0}", relativePast: "há {0}",
+		relativeUnits: [7]message{
+			units("one", "{0} segundo", "other", "{0} segundos"),
+			units("one", "{0} minuto", "other", "{0} minutos"),
+			units("one", "{0} hora", "other", "{0} horas"),
+			units("one", "{0} dia", "other", "{0} dias"),
+			units("one", "{0} semana", "other", "{0} semanas"),
+			units("one", "{0} mês", "other", "{0} meses"),
+			units("one", "{0} ano", "other", "{0} anos"),
+		},
+	},
+	"ru": {
+		decimal: ",", group: "\u00a0", minGrouping: 1, currencyPattern: "#\u00a0¤",
+		dateFormats: [4]string{"dd.MM.y", "d MMM y 'г'.", "d MMMM y 'г'.", "EEEE, d MMMM y 'г'."},
+		months:      [12]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		shortMonths: [12]string{"янв.", "февр.", "мар.", "апр.", "мая", "июн.", "июл.", "авг.", "сент.", "окт.", "нояб.", "дек."},
+		weekdays:    [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		now:         "сейчас", relativeFuture: "через {0}", relativePast: "{0} назад",
+		relativeUnits: [7]message{
+			units("one", "{0} секунду", "few", "{0} секунды", "many", "{0} секунд"),
+			units("one", "{0} минуту", "few", "{0} минуты", "many", "{0} минут"),
+			units("one", "{0} час", "few", "{0} часа", "many", "{0} часов"),
+			units("one", "{0} день", "few", "{0} дня", "many", "{0} дней"),
+			units("one", "{0} неделю", "few", "{0} недели", "many", "{0} недель"),
+			units("one", "{0} месяц", "few", "{0} месяца", "many", "{0} месяцев"),
+			units("one", "{0} год", "few", "{0} года", "many", "{0} лет"),
+		},
+	},
+	"ja": {
+		decimal: ".", group: ",", minGrouping: 1, currencyPattern: "¤#",
+		dateFormats: [4]string{"y/MM/dd", "y/MM/dd", "y年M月d日", "y年M月d日EEEE"},
+		months:      [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		shortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdays:    [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		now:         "今", relativeFuture: "{0}後", relativePast: "{0}前",
+		relativeUnits: [7]message{
+			units("other", "{0} 秒"),
+			units("other", "{0} 分"),
+			units("other", "{0} 時間"),
+			units("other", "{0} 日"),
+			units("other", "{0} 週間"),
+			units("other", "{0} か月"),
+			units("other", "{0} 年"),
+		},
+	},
+	"zh": {
+		decimal: ".", group: ",", minGrouping: 1, currencyPattern: "¤#",
+		dateFormats: [4]string{"y/M/d", "y年M月d日", "y年M月d日", "y年M月d日EEEE"},
+		months:      [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		shortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdays:    [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		now:         "现在", relativeFuture: "{0}后", relativePast: "{0}前",
+		relativeUnits: [7]message{
+			units("other", "{0}秒钟"),
+			units("other", "{0}分钟"),
+			units("other", "{0}小时"),
+			units("other", "{0}天"),
+			units("other", "{0}周"),
+			units("other", "{0}个月"),
+			units("other", "{0}年"),
+		},
+	},
+}
+
+// formatsFor returns the formats for a locale, using English for unsupported languages
+func formatsFor(locale string) localeFormats {
+	formats, ok := languageFormats[baseLanguage(locale)]
+	if !ok {
+		return englishFormats
+	}
+	if baseLanguage(locale) == "en" && locale != "" && !strings.EqualFold(locale, "en") && !strings.EqualFold(locale, "en-US") && !strings.EqualFold(locale, "en_US") {
+		formats.dateFormats = britishDateFormats
+	}
+	return formats
+}
+
+// currencySymbols are the symbols used in English, with any other currency shown by its ISO 4217 code
+var currencySymbols = map[string]string{
+	"AUD": "A$", "BRL": "R$", "CAD": "CA$", "CNY": "CN¥", "EUR": "€", "GBP": "£", "HKD": "HK$",
+	"ILS": "₪", "INR": "₹", "JPY": "¥", "KRW": "₩", "MXN": "MX$", "NZD": "NZ$", "USD": "$", "VND": "₫",
+}
+
+// currencyDigits are the fraction digits of currencies that don’t use 2
+var currencyDigits = map[string]int{
+	"CLP": 0, "ISK": 0, "JPY": 0, "KRW": 0, "VND": 0,
+	"BHD": 3, "JOD": 3, "KWD": 3, "OMR": 3, "TND": 3,
+}
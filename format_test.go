@@ -0,0 +1,98 @@
+package dovetail
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func renderWithLocale(locale string, view HTMLView) string {
+	b := new(bytes.Buffer)
+	RenderContext(WithLocale(context.Background(), locale), b, view)
+	return b.String()
+}
+
+func TestFormatNumber(t *testing.T) {
+	t.Run(`it uses the locale’s separators`, func(t *testing.T) {
+		assert.Equal(t, renderWithLocale("en", FormatNumber(1234567.891)), `1,234,567.891`)
+		assert.Equal(t, renderWithLocale("de", FormatNumber(1234567.891)), `1.234.567,891`)
+		assert.Equal(t, renderWithLocale("fr", FormatNumber(1234.5)), "1\u202f234,5")
+		assert.Equal(t, renderWithLocale("ru", FormatNumber(-1234.5)), "-1\u00a0234,5")
+	})
+
+	t.Run(`it only groups 4 digit numbers where the locale does`, func(t *testing.T) {
+		assert.Equal(t, renderWithLocale("es", FormatNumber(1234)), `1234`)
+		assert.Equal(t, renderWithLocale("es", FormatNumber(12345)), `12.345`)
+	})
+
+	t.Run(`it rounds to up to 3 fraction digits unless set`, func(t *testing.T) {
+		assert.Equal(t, renderWithLocale("en", FormatNumber(2.0/3)), `0.667`)
+		assert.Equal(t, renderWithLocale("en", FormatNumber(3)), `3`)
+		assert.Equal(t, renderWithLocale("en", FormatNumber(3).Fraction(2)), `3.00`)
+	})
+
+	t.Run(`it uses English without a locale`, func(t *testing.T) {
+		assert.Equal(t, subjectAsString(P(FormatNumber(1000))), `<p>1,000</p>`)
+	})
+}
+
+func TestFormatCurrency(t *testing.T) {
+	t.Run(`it places the symbol where the locale does`, func(t *testing.T) {
+		assert.Equal(t, renderWithLocale("en", FormatCurrency(1234.5, "USD")), `$1,234.50`)
+		assert.Equal(t, renderWithLocale("de", FormatCurrency(1234.5, "EUR")), "1.234,50\u00a0€")
+		assert.Equal(t, renderWithLocale("pt-BR", FormatCurrency(10, "BRL")), "R$\u00a010,00")
+	})
+
+	t.Run(`it uses the currency’s fraction digits`, func(t *testing.T) {
+		assert.Equal(t, renderWithLocale("ja", FormatCurrency(1500, "JPY")), `¥1,500`)
+	})
+
+	t.Run(`it writes the code of currencies without a symbol`, func(t *testing.T) {
+		assert.Equal(t, renderWithLocale("en", FormatCurrency(-10, "CHF")), "-CHF\u00a010.00")
+	})
+}
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2019, time.March, 4, 18, 30, 0, 0, time.UTC)
+
+	t.Run(`it wraps the date in <time>`, func(t *testing.T) {
+		assert.Equal(t, renderWithLocale("en-US", FormatDate(date)), `<time datetime="2019-03-04">Mar 4, 2019</time>`)
+	})
+
+	t.Run(`it uses the locale’s patterns`, func(t *testing.T) {
+		assert.Equal(t, renderWithLocale("en-GB", FormatDate(date).Style(DateShort)), `<time datetime="2019-03-04">04/03/2019</time>`)
+		assert.Equal(t, renderWithLocale("de", FormatDate(date).Style(DateLong)), `<time datetime="2019-03-04">4. März 2019</time>`)
+		assert.Equal(t, renderWithLocale("es", FormatDate(date).Style(DateFull)), `<time datetime="2019-03-04">lunes, 4 de marzo de 2019</time>`)
+		assert.Equal(t, renderWithLocale("ja", FormatDate(date).Style(DateFull)), `<time datetime="2019-03-04">2019年3月4日月曜日</time>`)
+	})
+
+	t.Run(`it can be enhanced`, func(t *testing.T) {
+		assert.Equal(t, subjectAsString(FormatDate(date).Use(Class("date"))), `<time datetime="2019-03-04" class="date">Mar 4, 2019</time>`)
+	})
+
+	t.Run(`it uses DateMedium for an unknown style and returns an error`, func(t *testing.T) {
+		b := new(bytes.Buffer)
+		err := Render(b, FormatDate(date).Style(DateStyle(7)))
+		assert.Equal(t, b.String(), `<time datetime="2019-03-04">Mar 4, 2019</time>`)
+		assert.Error(t, err, `dovetail: unknown DateStyle 7, using DateMedium`)
+	})
+}
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2019, time.March, 4, 18, 30, 0, 0, time.UTC)
+
+	t.Run(`it uses the largest whole unit`, func(t *testing.T) {
+		assert.Equal(t, renderWithLocale("en", FormatRelativeTime(now.Add(-3*24*time.Hour)).From(now)), `<time datetime="2019-03-01T18:30:00Z">3 days ago</time>`)
+		assert.Equal(t, renderWithLocale("en", FormatRelativeTime(now.Add(90*time.Minute)).From(now)), `<time datetime="2019-03-04T20:00:00Z">in 1 hour</time>`)
+		assert.Equal(t, renderWithLocale("en", FormatRelativeTime(now).From(now)), `<time datetime="2019-03-04T18:30:00Z">now</time>`)
+	})
+
+	t.Run(`it uses the locale’s plural forms`, func(t *testing.T) {
+		assert.Equal(t, renderWithLocale("ru", FormatRelativeTime(now.Add(-2*time.Minute)).From(now)), `<time datetime="2019-03-04T18:28:00Z">2 минуты назад</time>`)
+		assert.Equal(t, renderWithLocale("ru", FormatRelativeTime(now.Add(-5*time.Minute)).From(now)), `<time datetime="2019-03-04T18:25:00Z">5 минут назад</time>`)
+		assert.Equal(t, renderWithLocale("fr", FormatRelativeTime(now.Add(14*24*time.Hour)).From(now)), `<time datetime="2019-03-18T18:30:00Z">dans 2 semaines</time>`)
+	})
+}
@@ -8,4 +8,4 @@ require (
 	gotest.tools v2.2.0+incompatible
 )
 
-go 1.11
+go 1.14
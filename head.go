@@ -0,0 +1,197 @@
+package dovetail
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// headContent collects the nodes declared for the document’s <head> while building, de-duplicated by key
+type headContent struct {
+	keys  []string
+	nodes map[string]*html.Node
+}
+
+// add appends node, or replaces an earlier node with the same key so the last declaration wins
+func (head *headContent) add(node *html.Node) {
+	if head.nodes == nil {
+		head.nodes = make(map[string]*html.Node)
+	}
+
+	key := headKey(node)
+	if _, exists := head.nodes[key]; !exists {
+		head.keys = append(head.keys, key)
+	}
+	head.nodes[key] = node
+}
+
+// list returns the collected nodes in the order they were first declared
+func (head *headContent) list() []*html.Node {
+	nodes := make([]*html.Node, 0, len(head.keys))
+	for _, key := range head.keys {
+		nodes = append(nodes, head.nodes[key])
+	}
+	return nodes
+}
+
+func attrValue(node *html.Node, key string) (string, bool) {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// headKey identifies nodes that would conflict with each other in the <head>
+func headKey(node *html.Node) string {
+	switch node.DataAtom {
+	case atom.Title, atom.Base:
+		return node.Data
+	case atom.Meta:
+		if _, ok := attrValue(node, "charset"); ok {
+			return "meta charset"
+		}
+		for _, key := range []string{"name", "property", "http-equiv", "itemprop"} {
+			if value, ok := attrValue(node, key); ok {
+				return "meta " + key + "=" + value
+			}
+		}
+	case atom.Link:
+		rel, _ := attrValue(node, "rel")
+		if rel == "canonical" || rel == "manifest" {
+			return "link rel=" + rel
+		}
+		if href, ok := attrValue(node, "href"); ok {
+			return "link rel=" + rel + " href=" + href
+		}
+	case atom.Script:
+		if src, ok := attrValue(node, "src"); ok {
+			return "script src=" + src
+		}
+	}
+
+	// Anything else is only a duplicate if identical
+	b := new(bytes.Buffer)
+	html.Render(b, node)
+	return b.String()
+}
+
+// headView declares content for the <head> without adding children where it is used
+type headView struct {
+	views []HTMLView
+}
+
+// apply makes the node an empty fragment, so a Head used outside an element renders nothing in its place
+func (view headView) apply(node *html.Node, ctx *buildContext) {
+	node.Type = html.DocumentNode
+	view.collect(ctx)
+}
+
+// collect builds the views into the head content
+func (view headView) collect(ctx *buildContext) {
+	for _, headChild := range view.views {
+		if headChild == nil {
+			continue
+		}
+		ctx.head.add(ctx.build(headChild))
+	}
+}
+
+func (headView) enhances() bool { return true }
+
+// Head declares content such as Title or Stylesheet for the document’s <head>.
+// It can be used within any component, and is collected from the whole tree while rendering.
+// The content is only rendered by Document or RenderWithHead, and is discarded by Render otherwise.
+func Head(views ...HTMLView) HTMLEnhancer {
+	return headView{views: views}
+}
+
+// Title makes <title> for use within Head
+func Title(text string) HTMLElementView {
+	return HTMLElementViewOf("title", atom.Title, []HTMLView{Text(text)})
+}
+
+// Meta makes <meta name="…" content="…"> for use within Head
+func Meta(name string, content string) HTMLElementView {
+	return HTMLElementViewOf("meta", atom.Meta, []HTMLView{CustomAttr("name", name), CustomAttr("content", content)})
+}
+
+// MetaDescription makes <meta name="description"> for use within Head
+func MetaDescription(description string) HTMLElementView {
+	return Meta("description", description)
+}
+
+// Stylesheet makes <link rel="stylesheet"> for use within Head
+func Stylesheet(url string, enhancers ...HTMLEnhancer) HTMLElementView {
+	return HTMLElementViewOf("link", atom.Link, []HTMLView{CustomAttr("rel", "stylesheet"), CustomAttr("href", url)}).Use(enhancers...)
+}
+
+// Preload makes <link rel="preload"> for use within Head, where as is the type of content such as "font" or "image"
+func Preload(url string, as string, enhancers ...HTMLEnhancer) HTMLElementView {
+	return HTMLElementViewOf("link", atom.Link, []HTMLView{CustomAttr("rel", "preload"), CustomAttr("href", url), CustomAttr("as", as)}).Use(enhancers...)
+}
+
+// DocumentView makes a whole HTML document, with a <head> containing everything declared by Head
+type DocumentView struct {
+	bodyCore HTMLElementCore
+}
+
+// Document makes <!DOCTYPE html><html><head>…</head><body>{ children }</body></html>.
+// The lang and dir attributes of <html> are set from the locale when rendered with a context from WithLocale.
+func Document(children ...HTMLView) DocumentView {
+	return DocumentView{bodyCore: HTMLElementCore{children: children}}
+}
+
+func (doc DocumentView) apply(node *html.Node, ctx *buildContext) {
+	node.Type = html.DocumentNode
+	node.AppendChild(&html.Node{Type: html.DoctypeNode, Data: "html"})
+
+	htmlEl := &html.Node{Type: html.ElementNode, Data: "html", DataAtom: atom.Html}
+	if locale := Locale(ctx.context()); locale != "" {
+		htmlEl.Attr = []html.Attribute{{Key: "lang", Val: locale}, {Key: "dir", Val: TextDirection(locale)}}
+	}
+	headEl := &html.Node{Type: html.ElementNode, Data: "head", DataAtom: atom.Head}
+	bodyEl := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+
+	// The body is built first so all its Head declarations are collected
+	doc.bodyCore.applyToNode(bodyEl, ctx)
+
+	headEl.AppendChild(&html.Node{Type: html.ElementNode, Data: "meta", DataAtom: atom.Meta, Attr: []html.Attribute{{Key: "charset", Val: "utf-8"}}})
+	for _, headNode := range ctx.head.list() {
+		if headKey(headNode) == "meta charset" {
+			continue
+		}
+		headEl.AppendChild(headNode)
+	}
+
+	htmlEl.AppendChild(headEl)
+	htmlEl.AppendChild(bodyEl)
+	node.AppendChild(htmlEl)
+}
+
+// RenderWithHead renders views to body, and the content they declare with Head to head.
+// This is used when the page already exists, such as updating the document from WebAssembly.
+func RenderWithHead(body io.Writer, head io.Writer, views ...HTMLView) error {
+	return RenderWithHeadContext(context.Background(), body, head, views...)
+}
+
+// RenderWithHeadContext renders like RenderWithHead, with values from the request’s context such as the CSP nonce and locale
+func RenderWithHeadContext(requestContext context.Context, body io.Writer, head io.Writer, views ...HTMLView) error {
+	ctx := newBuildContext()
+	ctx.requestContext = requestContext
+	for _, view := range views {
+		if err := html.Render(body, ctx.build(view)); err != nil {
+			return err
+		}
+	}
+	for _, headNode := range ctx.head.list() {
+		if err := html.Render(head, headNode); err != nil {
+			return err
+		}
+	}
+	return ctx.err()
+}
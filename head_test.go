@@ -0,0 +1,78 @@
+package dovetail
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestHead(t *testing.T) {
+	t.Run("Rendering Document with Head declared by nested components", func(t *testing.T) {
+		s := subjectAsString(Document(
+			Head(Title("Site"), Stylesheet("/app.css")),
+			Main(
+				Head(MetaDescription("About us")),
+				Article(
+					Head(Title("About"), Stylesheet("/app.css"), Preload("/font.woff2", "font", CustomAttr("crossorigin", ""))),
+					H(1, Text("About")),
+				),
+			),
+		))
+
+		t.Run(`it renders the collected and de-duplicated content into <head>`, func(t *testing.T) {
+			assert.Equal(t, s, strings.Replace(`<!DOCTYPE html><html>
+<head><meta charset="utf-8"/><title>About</title><link rel="stylesheet" href="/app.css"/><meta name="description" content="About us"/><link rel="preload" href="/font.woff2" as="font" crossorigin=""/></head>
+<body><main><article><h1>About</h1></article></main></body>
+</html>`, "\n", "", -1))
+		})
+	})
+
+	t.Run("Rendering Head within an element's enhancers", func(t *testing.T) {
+		s := subjectAsString(Document(
+			Img("/sunrise.jpg", "Sunrise", Head(Preload("/sunrise.jpg", "image"))),
+		))
+
+		t.Run(`it does not add children to the element`, func(t *testing.T) {
+			assert.Equal(t, s, `<!DOCTYPE html><html><head><meta charset="utf-8"/><link rel="preload" href="/sunrise.jpg" as="image"/></head><body><img src="/sunrise.jpg" alt="Sunrise"/></body></html>`)
+		})
+	})
+
+	t.Run("Rendering Head outside Document", func(t *testing.T) {
+		b := new(bytes.Buffer)
+		err := Render(b, Head(Title("Discarded")), P(Text("Kept")))
+
+		t.Run(`it renders nothing in place of the head content`, func(t *testing.T) {
+			assert.NilError(t, err)
+			assert.Equal(t, b.String(), `<p>Kept</p>`)
+		})
+	})
+
+	t.Run("RenderWithHead", func(t *testing.T) {
+		body := new(bytes.Buffer)
+		head := new(bytes.Buffer)
+		RenderWithHead(body, head, Div(Head(Title("First")), Text("Hello")), Div(Head(Title("Second"))))
+
+		t.Run(`it renders the body`, func(t *testing.T) {
+			assert.Equal(t, body.String(), `<div>Hello</div><div></div>`)
+		})
+
+		t.Run(`it renders the head content separately, with the last title winning`, func(t *testing.T) {
+			assert.Equal(t, head.String(), `<title>Second</title>`)
+		})
+	})
+
+	t.Run("RenderWithHeadContext", func(t *testing.T) {
+		body := new(bytes.Buffer)
+		head := new(bytes.Buffer)
+		err := RenderWithHeadContext(WithCSPNonce(context.Background(), "abc123"), body, head, Div(Head(InlineStyle(`p { margin: 0 }`)), InlineScript(`go()`)))
+
+		t.Run(`it adds the nonce from the context to the body and head`, func(t *testing.T) {
+			assert.NilError(t, err)
+			assert.Equal(t, body.String(), `<div><script nonce="abc123">go()</script></div>`)
+			assert.Equal(t, head.String(), `<style nonce="abc123">p { margin: 0 }</style>`)
+		})
+	})
+}
@@ -9,3 +9,10 @@ func subjectAsString(view HTMLView) string {
 	Render(b, view)
 	return b.String()
 }
+
+// renderScriptEnhanced renders view as the WebAssembly build does, where scriptEnhanced is set
+func renderScriptEnhanced(view func() HTMLView) string {
+	scriptEnhanced = true
+	defer func() { scriptEnhanced = false }()
+	return subjectAsString(view())
+}
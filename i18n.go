@@ -0,0 +1,423 @@
+package dovetail
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// message is a translation, with text for each plural category, or just "other" if it has no plural forms
+type message map[string]string
+
+// Catalog holds translated messages for each locale, loaded from JSON or gettext PO files
+type Catalog struct {
+	defaultLocale string
+	locales       map[string]map[string]message
+}
+
+// NewCatalog makes an empty catalog, where messages missing from a locale fall back to defaultLocale
+func NewCatalog(defaultLocale string) *Catalog {
+	return &Catalog{defaultLocale: defaultLocale, locales: make(map[string]map[string]message)}
+}
+
+// LoadCatalog loads every .json and .po file in fs, each named by its locale such as "en.json" or "pt-BR.po"
+func LoadCatalog(fs http.FileSystem, defaultLocale string) (*Catalog, error) {
+	dir, err := fs.Open("/")
+	if err != nil {
+		return nil, err
+	}
+	infos, err := dir.Readdir(-1)
+	dir.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := NewCatalog(defaultLocale)
+	for _, info := range infos {
+		ext := path.Ext(info.Name())
+		if info.IsDir() || (ext != ".json" && ext != ".po") {
+			continue
+		}
+
+		f, err := fs.Open("/" + info.Name())
+		if err != nil {
+			return nil, err
+		}
+		locale := strings.TrimSuffix(info.Name(), ext)
+		if ext == ".json" {
+			err = catalog.AddJSON(locale, f)
+		} else {
+			err = catalog.AddPO(locale, f)
+		}
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("dovetail: catalog %s: %v", info.Name(), err)
+		}
+	}
+	return catalog, nil
+}
+
+// add adds the message for the locale, leaving out empty forms such as those written by dovetail extract for translators to fill in.
+// A message with no forms left is untranslated, so it is skipped and looked up in other locales instead.
+func (catalog *Catalog) add(locale string, key string, msg message) {
+	for category, text := range msg {
+		if text == "" {
+			delete(msg, category)
+		}
+	}
+	if len(msg) == 0 {
+		return
+	}
+
+	messages, ok := catalog.locales[locale]
+	if !ok {
+		messages = make(map[string]message)
+		catalog.locales[locale] = messages
+	}
+	messages[key] = msg
+}
+
+// Add adds a message for the locale, where {name} is replaced by the parameter with that name. Empty text is skipped as untranslated.
+func (catalog *Catalog) Add(locale string, key string, text string) *Catalog {
+	catalog.add(locale, key, message{PluralOther: text})
+	return catalog
+}
+
+// AddPlural adds a message with text for each plural category, such as "one" and "other". Empty forms are skipped as untranslated.
+func (catalog *Catalog) AddPlural(locale string, key string, forms map[string]string) *Catalog {
+	msg := make(message, len(forms))
+	for category, text := range forms {
+		msg[category] = text
+	}
+	catalog.add(locale, key, msg)
+	return catalog
+}
+
+// AddJSON adds messages from a JSON object, where each value is the text or an object of plural forms:
+// {"greeting": "Hello, {name}", "items": {"one": "{count} item", "other": "{count} items"}}
+// Empty strings are skipped as untranslated.
+func (catalog *Catalog) AddJSON(locale string, r io.Reader) error {
+	var entries map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return err
+	}
+
+	for key, raw := range entries {
+		var text string
+		if err := json.Unmarshal(raw, &text); err == nil {
+			catalog.Add(locale, key, text)
+			continue
+		}
+
+		var forms map[string]string
+		if err := json.Unmarshal(raw, &forms); err != nil {
+			return fmt.Errorf("message %q must be a string or an object of plural forms", key)
+		}
+		catalog.AddPlural(locale, key, forms)
+	}
+	return nil
+}
+
+// AddPO adds messages from a gettext PO file, where the msgid is the key.
+// The plural forms msgstr[0], msgstr[1], … are the locale’s plural categories in CLDR order, such as one then other.
+// Untranslated messages are skipped.
+func (catalog *Catalog) AddPO(locale string, r io.Reader) error {
+	categories := PluralCategories(locale)
+
+	var key string
+	forms := message{}
+	// field is where continuation lines are added, such as "msgid" or a plural category
+	field := ""
+	flush := func() {
+		if key != "" {
+			catalog.add(locale, key, forms)
+		}
+		key, forms, field = "", message{}, ""
+	}
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		keyword, quoted := "", line
+		if !strings.HasPrefix(line, `"`) {
+			space := strings.IndexByte(line, ' ')
+			if space < 0 {
+				return fmt.Errorf("line %d: expected a quoted string", lineNumber)
+			}
+			keyword, quoted = line[:space], strings.TrimSpace(line[space+1:])
+		}
+		text, err := strconv.Unquote(quoted)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid string %s", lineNumber, quoted)
+		}
+
+		switch {
+		case keyword == "":
+			// A string continuing the previous line
+		case keyword == "msgctxt":
+			flush()
+			field = "ignored"
+			continue
+		case keyword == "msgid":
+			if field != "ignored" {
+				flush()
+			}
+			field = "msgid"
+		case keyword == "msgid_plural":
+			field = "ignored"
+			continue
+		case keyword == "msgstr":
+			field = PluralOther
+		case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+			index, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+			if err != nil {
+				return fmt.Errorf("line %d: invalid plural index %s", lineNumber, keyword)
+			}
+			field = "ignored"
+			if index < len(categories) {
+				field = categories[index]
+			}
+		default:
+			return fmt.Errorf("line %d: unknown keyword %s", lineNumber, keyword)
+		}
+
+		switch field {
+		case "":
+			return fmt.Errorf("line %d: unexpected string", lineNumber)
+		case "ignored":
+		case "msgid":
+			key += text
+		default:
+			forms[field] += text
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	flush()
+	return nil
+}
+
+// Locales returns the locales with messages, sorted
+func (catalog *Catalog) Locales() []string {
+	locales := make([]string, 0, len(catalog.locales))
+	for locale := range catalog.locales {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// matchLocale returns the catalog’s locale for a language tag, matching "pt-BR" exactly or else its language "pt"
+func (catalog *Catalog) matchLocale(tag string) (string, bool) {
+	for locale := range catalog.locales {
+		if strings.EqualFold(locale, tag) {
+			return locale, true
+		}
+	}
+	locales := catalog.Locales()
+	for _, locale := range locales {
+		if strings.EqualFold(locale, baseLanguage(tag)) {
+			return locale, true
+		}
+	}
+	for _, locale := range locales {
+		if baseLanguage(locale) == baseLanguage(tag) {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate returns the best locale for an Accept-Language header, or the default locale if none match
+func (catalog *Catalog) Negotiate(acceptLanguage string) string {
+	type weightedTag struct {
+		tag    string
+		weight float64
+	}
+
+	var tags []weightedTag
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		weight := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					weight = q
+				}
+			}
+		}
+		if weight > 0 {
+			tags = append(tags, weightedTag{tag: tag, weight: weight})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].weight > tags[j].weight })
+
+	for _, tag := range tags {
+		if locale, ok := catalog.matchLocale(tag.tag); ok {
+			return locale
+		}
+	}
+	return catalog.defaultLocale
+}
+
+// Middleware chooses the locale from the request’s Accept-Language header.
+// Views rendered with RenderContext using the request’s context are translated into that locale.
+func (catalog *Catalog) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := catalog.Negotiate(r.Header.Get("Accept-Language"))
+		w.Header().Add("Vary", "Accept-Language")
+		w.Header().Set("Content-Language", locale)
+
+		ctx := WithCatalog(WithLocale(r.Context(), locale), catalog)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// lookup finds the text for a message in the locale, then in its language, then in the default locale
+func (catalog *Catalog) lookup(locale string, key string, count int, plural bool) (string, bool) {
+	candidates := []string{locale}
+	if matched, ok := catalog.matchLocale(locale); ok {
+		candidates = append(candidates, matched)
+	}
+	candidates = append(candidates, catalog.defaultLocale)
+
+	for _, candidate := range candidates {
+		msg, ok := catalog.locales[candidate][key]
+		if !ok {
+			continue
+		}
+		if plural {
+			if text, ok := msg[PluralCategory(candidate, count)]; ok {
+				return text, true
+			}
+		}
+		if text, ok := msg[PluralOther]; ok {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+type localeKey struct{}
+type catalogKey struct{}
+
+// WithLocale returns a context carrying the locale, such as "en" or "pt-BR", used by views rendered with RenderContext
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// Locale returns the locale for the request, or "" if there is none
+func Locale(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
+
+// WithCatalog returns a context carrying the catalog used to translate T and Plural
+func WithCatalog(ctx context.Context, catalog *Catalog) context.Context {
+	return context.WithValue(ctx, catalogKey{}, catalog)
+}
+
+// CatalogFrom returns the catalog for the request, or nil if there is none
+func CatalogFrom(ctx context.Context) *Catalog {
+	catalog, _ := ctx.Value(catalogKey{}).(*Catalog)
+	return catalog
+}
+
+// MessageParam is a named value replacing {name} within a message
+type MessageParam struct {
+	name  string
+	value interface{}
+}
+
+// Param makes a parameter for T or Plural
+func Param(name string, value interface{}) MessageParam {
+	return MessageParam{name: name, value: value}
+}
+
+// MessageView renders a message from the catalog, translated into the request’s locale
+type MessageView struct {
+	key    string
+	count  int
+	plural bool
+	params []MessageParam
+}
+
+// T renders the message for key, replacing {name} placeholders with the parameters
+func T(key string, params ...MessageParam) MessageView {
+	return MessageView{key: key, params: params}
+}
+
+// Plural renders the message for key using the plural form for count, which is also available as {count} formatted for the locale
+func Plural(key string, count int, params ...MessageParam) MessageView {
+	return MessageView{key: key, count: count, plural: true, params: params}
+}
+
+// translate returns the message in the locale, or the key if the catalog has no message for it
+func (view MessageView) translate(catalog *Catalog, locale string) (string, error) {
+	text := view.key
+	var err error
+	if catalog == nil {
+		err = fmt.Errorf("dovetail: no catalog to translate message %q", view.key)
+	} else if found, ok := catalog.lookup(locale, view.key, view.count, view.plural); ok {
+		text = found
+	} else {
+		err = fmt.Errorf("dovetail: no message %q for locale %q", view.key, locale)
+	}
+
+	replacements := make([]string, 0, 2*len(view.params)+2)
+	if view.plural {
+		replacements = append(replacements, "{count}", formatNumber(formatsFor(locale), float64(view.count), 0))
+	}
+	for _, param := range view.params {
+		replacements = append(replacements, "{"+param.name+"}", fmt.Sprint(param.value))
+	}
+	return strings.NewReplacer(replacements...).Replace(text), err
+}
+
+func (view MessageView) apply(node *html.Node, ctx *buildContext) {
+	text, err := view.translate(CatalogFrom(ctx.context()), Locale(ctx.context()))
+	if err != nil {
+		ctx.report(err)
+	}
+
+	node.Type = html.TextNode
+	node.Data = text
+}
+
+// rtlLanguages are written right-to-left
+var rtlLanguages = map[string]bool{
+	"ar": true, "arc": true, "ckb": true, "dv": true, "fa": true, "he": true,
+	"ks": true, "ps": true, "sd": true, "ug": true, "ur": true, "yi": true,
+}
+
+// TextDirection returns "rtl" for locales written right-to-left such as Arabic and Hebrew, otherwise "ltr"
+func TextDirection(locale string) string {
+	if rtlLanguages[baseLanguage(locale)] {
+		return "rtl"
+	}
+	return "ltr"
+}
+
+// Lang adds lang and dir attributes, for content in a different language to the rest of the document
+func Lang(locale string) HTMLEnhancer {
+	return combinedView{views: []HTMLView{CustomAttr("lang", locale), CustomAttr("dir", TextDirection(locale))}}
+}
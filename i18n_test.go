@@ -0,0 +1,191 @@
+package dovetail
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func renderInLocale(catalog *Catalog, locale string, view HTMLView) (string, error) {
+	b := new(bytes.Buffer)
+	err := RenderContext(WithCatalog(WithLocale(context.Background(), locale), catalog), b, view)
+	return b.String(), err
+}
+
+func TestCatalog(t *testing.T) {
+	catalog := NewCatalog("en").
+		Add("en", "greeting", "Hello, {name}!").
+		Add("fr", "greeting", "Bonjour, {name} !").
+		AddPlural("en", "cart.items", map[string]string{"one": "{count} item", "other": "{count} items"}).
+		AddPlural("ru", "cart.items", map[string]string{"one": "{count} товар", "few": "{count} товара", "many": "{count} товаров"}).
+		Add("en", "only.english", "Only in English")
+
+	t.Run("Translating messages", func(t *testing.T) {
+		s, err := renderInLocale(catalog, "fr-CA", P(T("greeting", Param("name", "Zoë"))))
+
+		t.Run(`it uses the message for the locale’s language`, func(t *testing.T) {
+			assert.NilError(t, err)
+			assert.Equal(t, s, `<p>Bonjour, Zoë !</p>`)
+		})
+
+		s, err = renderInLocale(catalog, "fr", P(T("only.english")))
+
+		t.Run(`it falls back to the default locale`, func(t *testing.T) {
+			assert.NilError(t, err)
+			assert.Equal(t, s, `<p>Only in English</p>`)
+		})
+
+		s, err = renderInLocale(catalog, "en", P(T("missing")))
+
+		t.Run(`it renders the key and reports a missing message`, func(t *testing.T) {
+			assert.Equal(t, s, `<p>missing</p>`)
+			assert.Error(t, err, `dovetail: no message "missing" for locale "en"`)
+		})
+
+		s, err = renderInLocale(catalog, "en", P(T("greeting", Param("name", "<b>"))))
+
+		t.Run(`it escapes parameters`, func(t *testing.T) {
+			assert.NilError(t, err)
+			assert.Equal(t, s, `<p>Hello, &lt;b&gt;!</p>`)
+		})
+	})
+
+	t.Run("Translating plurals", func(t *testing.T) {
+		render := func(locale string, count int) string {
+			s, err := renderInLocale(catalog, locale, Plural("cart.items", count))
+			assert.NilError(t, err)
+			return s
+		}
+
+		t.Run(`it chooses the form for the count`, func(t *testing.T) {
+			assert.Equal(t, render("en", 1), `1 item`)
+			assert.Equal(t, render("en", 3), `3 items`)
+			assert.Equal(t, render("ru", 1), `1 товар`)
+			assert.Equal(t, render("ru", 3), `3 товара`)
+			assert.Equal(t, render("ru", 5), `5 товаров`)
+		})
+	})
+
+	t.Run("Negotiating the locale", func(t *testing.T) {
+		t.Run(`it prefers the highest quality match`, func(t *testing.T) {
+			assert.Equal(t, catalog.Negotiate("de;q=0.9, fr-FR;q=0.8, en;q=0.5"), "fr")
+			assert.Equal(t, catalog.Negotiate("ru, en;q=0.9"), "ru")
+		})
+
+		t.Run(`it falls back to the default locale`, func(t *testing.T) {
+			assert.Equal(t, catalog.Negotiate("de"), "en")
+			assert.Equal(t, catalog.Negotiate(""), "en")
+		})
+
+		handler := catalog.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			RenderContext(r.Context(), w, Document(T("greeting", Param("name", "Ana"))))
+		}))
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept-Language", "fr")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		t.Run(`it renders the document in the request’s locale`, func(t *testing.T) {
+			assert.Equal(t, w.Header().Get("Content-Language"), "fr")
+			assert.Equal(t, w.Header().Get("Vary"), "Accept-Language")
+			assert.Equal(t, w.Body.String(), `<!DOCTYPE html><html lang="fr" dir="ltr"><head><meta charset="utf-8"/></head><body>Bonjour, Ana !</body></html>`)
+		})
+	})
+}
+
+func TestLang(t *testing.T) {
+	t.Run(`it adds lang and dir attributes`, func(t *testing.T) {
+		s := subjectAsString(P(Text("Shalom: "), Q(Lang("he"), Text("שלום"))))
+		assert.Equal(t, s, `<p>Shalom: <q lang="he" dir="rtl">שלום</q></p>`)
+	})
+
+	t.Run(`it sets the document direction for right-to-left locales`, func(t *testing.T) {
+		b := new(bytes.Buffer)
+		RenderContext(WithLocale(context.Background(), "ar-EG"), b, Document())
+		assert.Assert(t, strings.HasPrefix(b.String(), `<!DOCTYPE html><html lang="ar-EG" dir="rtl">`))
+	})
+}
+
+func TestLoadCatalog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dovetail-catalog")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "en.json"), []byte(`{
+  "greeting": "Hello, {name}!",
+  "cart.items": {"one": "{count} item", "other": "{count} items"}
+}`), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "pl.po"), []byte(`# Polish
+msgid ""
+msgstr ""
+"Language: pl\n"
+
+msgid "greeting"
+msgstr "Cześć, "
+"{name}!"
+
+msgid "cart.items"
+msgid_plural "cart.items"
+msgstr[0] "{count} produkt"
+msgstr[1] "{count} produkty"
+msgstr[2] "{count} produktów"
+
+msgid "untranslated"
+msgstr ""
+`), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "de.json"), []byte(`{
+  "greeting": "Hallo, {name}!",
+  "cart.items": {"one": "", "other": ""},
+  "untranslated": ""
+}`), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte(`ignored`), 0644))
+
+	catalog, err := LoadCatalog(http.Dir(dir), "en")
+	assert.NilError(t, err)
+
+	t.Run(`it loads each locale`, func(t *testing.T) {
+		assert.DeepEqual(t, catalog.Locales(), []string{"de", "en", "pl"})
+	})
+
+	t.Run(`it loads JSON messages`, func(t *testing.T) {
+		s, err := renderInLocale(catalog, "en", Plural("cart.items", 2))
+		assert.NilError(t, err)
+		assert.Equal(t, s, `2 items`)
+	})
+
+	t.Run(`it loads PO messages, joining continued strings`, func(t *testing.T) {
+		s, err := renderInLocale(catalog, "pl", T("greeting", Param("name", "Ola")))
+		assert.NilError(t, err)
+		assert.Equal(t, s, `Cześć, Ola!`)
+	})
+
+	t.Run(`it maps PO plural forms to the locale’s categories`, func(t *testing.T) {
+		s, err := renderInLocale(catalog, "pl", Div(Plural("cart.items", 1), Plural("cart.items", 3), Plural("cart.items", 12)))
+		assert.NilError(t, err)
+		assert.Equal(t, s, `<div>1 produkt3 produkty12 produktów</div>`)
+	})
+
+	t.Run(`it skips untranslated PO messages`, func(t *testing.T) {
+		_, ok := catalog.locales["pl"]["untranslated"]
+		assert.Equal(t, ok, false)
+	})
+
+	t.Run(`it skips untranslated JSON messages, falling back to the default locale`, func(t *testing.T) {
+		_, ok := catalog.locales["de"]["untranslated"]
+		assert.Equal(t, ok, false)
+		_, ok = catalog.locales["de"]["cart.items"]
+		assert.Equal(t, ok, false)
+
+		s, err := renderInLocale(catalog, "de", Div(T("greeting", Param("name", "Ola")), Text(" "), Plural("cart.items", 2)))
+		assert.NilError(t, err)
+		assert.Equal(t, s, `<div>Hallo, Ola! 2 items</div>`)
+	})
+}
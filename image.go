@@ -0,0 +1,82 @@
+package dovetail
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html/atom"
+)
+
+// ImageCandidate is an image URL with a width or pixel density descriptor, for SrcSet
+type ImageCandidate struct {
+	url        string
+	descriptor string
+}
+
+// ImageWidth is a candidate image that is width pixels wide, such as "photo-640.jpg 640w"
+func ImageWidth(url string, width int) ImageCandidate {
+	return ImageCandidate{url: url, descriptor: strconv.Itoa(width) + "w"}
+}
+
+// ImageDensity is a candidate image for screens with the pixel density, such as "photo@2x.jpg 2x"
+func ImageDensity(url string, density float64) ImageCandidate {
+	return ImageCandidate{url: url, descriptor: strconv.FormatFloat(density, 'f', -1, 64) + "x"}
+}
+
+type imageCandidates []ImageCandidate
+
+// srcsetURLEscaper percent-encodes the commas and whitespace that would split a URL within srcset
+var srcsetURLEscaper = strings.NewReplacer(",", "%2C", " ", "%20", "\t", "%09", "\n", "%0A", "\f", "%0C", "\r", "%0D")
+
+func (candidates imageCandidates) String() string {
+	parts := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		parts = append(parts, srcsetURLEscaper.Replace(safeURL(candidate.url))+" "+candidate.descriptor)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// SrcSet sets the srcset attribute, letting the browser choose the best image for the screen.
+// URLs are checked like src, so unsafe URLs are replaced with a URL that does nothing, and commas and spaces within them are percent-encoded.
+func SrcSet(candidates ...ImageCandidate) HTMLAttrView {
+	return HTMLAttrView{Key: "srcset", Value: imageCandidates(candidates).String()}
+}
+
+// Sizes sets the sizes attribute, the width the image is displayed at for media conditions, such as "(min-width: 800px) 50vw", "100vw"
+func Sizes(sizes ...string) HTMLAttrView {
+	return HTMLAttrView{Key: "sizes", Value: strings.Join(sizes, ", ")}
+}
+
+// ImageSize sets the intrinsic width and height of the image, so space is reserved before it loads
+func ImageSize(width int, height int) HTMLEnhancer {
+	return combinedView{views: []HTMLView{
+		HTMLAttrView{Key: "width", Value: strconv.Itoa(width)},
+		HTMLAttrView{Key: "height", Value: strconv.Itoa(height)},
+	}}
+}
+
+// LazyLoading waits to load the image or iframe until it is near the viewport
+var LazyLoading = HTMLAttrView{Key: "loading", Value: "lazy"}
+
+// DecodingAsync lets the image decode without delaying other content
+var DecodingAsync = HTMLAttrView{Key: "decoding", Value: "async"}
+
+// Media sets the media condition of <source>, such as "(min-width: 800px)"
+func Media(query string) HTMLAttrView {
+	return HTMLAttrView{Key: "media", Value: query}
+}
+
+// DecorativeImg makes an <img> that is hidden from assistive technology, such as a background pattern
+func DecorativeImg(srcURL string, enhancers ...HTMLEnhancer) HTMLElementView {
+	return Img(srcURL, "", enhancers...).Use(CustomAttr("role", "presentation"))
+}
+
+// Picture makes <picture>, usually with Source children for each format followed by a fallback Img
+func Picture(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("picture", atom.Picture, children)
+}
+
+// Source makes <source> for Picture with the image’s MIME type, such as "image/avif" or "image/webp"
+func Source(mimeType string, candidates ...ImageCandidate) HTMLElementView {
+	return HTMLElementViewOf("source", atom.Source, []HTMLView{CustomAttr("type", mimeType), SrcSet(candidates...)})
+}
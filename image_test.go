@@ -0,0 +1,55 @@
+package dovetail
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestResponsiveImages(t *testing.T) {
+	t.Run("Rendering Img with srcset, sizes and loading options", func(t *testing.T) {
+		s := subjectAsString(Img("/photo-640.jpg", "A harbour at dusk",
+			SrcSet(ImageWidth("/photo-640.jpg", 640), ImageWidth("/photo-1280.jpg", 1280), ImageWidth("javascript:alert(1)", 1)),
+			Sizes("(min-width: 800px) 50vw", "100vw"),
+			ImageSize(1280, 853),
+			LazyLoading,
+			DecodingAsync,
+		))
+
+		t.Run(`it renders the attributes`, func(t *testing.T) {
+			assert.Equal(t, s, `<img src="/photo-640.jpg" alt="A harbour at dusk" srcset="/photo-640.jpg 640w, /photo-1280.jpg 1280w, about:invalid#zDovetailz 1w" sizes="(min-width: 800px) 50vw, 100vw" width="1280" height="853" loading="lazy" decoding="async"/>`)
+		})
+	})
+
+	t.Run("Rendering srcset with commas and spaces in URLs", func(t *testing.T) {
+		s := subjectAsString(Img("/photo.jpg", "", SrcSet(ImageDensity("/resize/w_640,h_480/my photo.jpg", 1), ImageDensity("/photo.jpg?crop=1,2", 2))))
+
+		t.Run(`it percent-encodes them so the candidates stay separate`, func(t *testing.T) {
+			assert.Equal(t, s, `<img src="/photo.jpg" alt="" srcset="/resize/w_640%2Ch_480/my%20photo.jpg 1x, /photo.jpg?crop=1%2C2 2x"/>`)
+		})
+	})
+
+	t.Run("Rendering Picture with a source per format", func(t *testing.T) {
+		s := subjectAsString(Picture(
+			Source("image/avif", ImageDensity("/logo.avif", 1), ImageDensity("/logo@2x.avif", 2)),
+			Source("image/webp", ImageDensity("/logo.webp", 1), ImageDensity("/logo@1.5x.webp", 1.5)).Use(Media("(min-width: 600px)")),
+			Img("/logo.png", "Dovetail"),
+		))
+
+		t.Run(`it renders <source> elements before the fallback <img>`, func(t *testing.T) {
+			assert.Equal(t, s, `<picture>`+
+				`<source type="image/avif" srcset="/logo.avif 1x, /logo@2x.avif 2x"/>`+
+				`<source type="image/webp" srcset="/logo.webp 1x, /logo@1.5x.webp 1.5x" media="(min-width: 600px)"/>`+
+				`<img src="/logo.png" alt="Dovetail"/>`+
+				`</picture>`)
+		})
+	})
+
+	t.Run("Rendering DecorativeImg", func(t *testing.T) {
+		s := subjectAsString(DecorativeImg("/divider.svg", LazyLoading))
+
+		t.Run(`it renders an empty alt and role presentation`, func(t *testing.T) {
+			assert.Equal(t, s, `<img src="/divider.svg" alt="" loading="lazy" role="presentation"/>`)
+		})
+	})
+}
@@ -0,0 +1,199 @@
+package dovetail
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// blockAtoms are elements that lay out as blocks, so whitespace between them does not change the rendered page
+var blockAtoms = map[atom.Atom]bool{
+	atom.Address:    true,
+	atom.Article:    true,
+	atom.Aside:      true,
+	atom.Blockquote: true,
+	atom.Body:       true,
+	atom.Caption:    true,
+	atom.Col:        true,
+	atom.Colgroup:   true,
+	atom.Dd:         true,
+	atom.Details:    true,
+	atom.Dialog:     true,
+	atom.Div:        true,
+	atom.Dl:         true,
+	atom.Dt:         true,
+	atom.Fieldset:   true,
+	atom.Figcaption: true,
+	atom.Figure:     true,
+	atom.Footer:     true,
+	atom.Form:       true,
+	atom.H1:         true,
+	atom.H2:         true,
+	atom.H3:         true,
+	atom.H4:         true,
+	atom.H5:         true,
+	atom.H6:         true,
+	atom.Head:       true,
+	atom.Header:     true,
+	atom.Hgroup:     true,
+	atom.Hr:         true,
+	atom.Html:       true,
+	atom.Legend:     true,
+	atom.Li:         true,
+	atom.Main:       true,
+	atom.Menu:       true,
+	atom.Nav:        true,
+	atom.Ol:         true,
+	atom.P:          true,
+	atom.Pre:        true,
+	atom.Section:    true,
+	atom.Summary:    true,
+	atom.Table:      true,
+	atom.Tbody:      true,
+	atom.Td:         true,
+	atom.Tfoot:      true,
+	atom.Th:         true,
+	atom.Thead:      true,
+	atom.Tr:         true,
+	atom.Ul:         true,
+
+	// Metadata is never displayed, so whitespace around it is also insignificant
+	atom.Base:     true,
+	atom.Link:     true,
+	atom.Meta:     true,
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Template: true,
+	atom.Title:    true,
+}
+
+// whitespaceSensitiveAtoms are blocks whose content must never be indented
+var whitespaceSensitiveAtoms = map[atom.Atom]bool{
+	atom.Pre:      true,
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Template: true,
+	atom.Title:    true,
+}
+
+func isBlockNode(node *html.Node) bool {
+	switch node.Type {
+	case html.ElementNode:
+		return blockAtoms[node.DataAtom]
+	case html.DoctypeNode, html.CommentNode:
+		return true
+	}
+	return false
+}
+
+// hasOnlyBlockChildren reports whether node’s children can each go on their own line
+func hasOnlyBlockChildren(node *html.Node) bool {
+	if node.FirstChild == nil {
+		return false
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if !isBlockNode(child) {
+			return false
+		}
+	}
+	return true
+}
+
+// RenderIndented renders views like Render, but with block-level elements on their own lines, indented by indent.
+// Newlines are only added between block-level elements, so inline elements, text, and the content of
+// <pre> and <textarea> are rendered as-is, and the page displays the same as when rendered compactly.
+func RenderIndented(w io.Writer, indent string, views ...HTMLView) error {
+	return RenderIndentedContext(context.Background(), w, indent, views...)
+}
+
+// RenderIndentedContext renders views like RenderIndented, with values from the request’s context such as the CSP nonce and locale
+func RenderIndentedContext(requestContext context.Context, w io.Writer, indent string, views ...HTMLView) error {
+	ctx := newBuildContext()
+	ctx.requestContext = requestContext
+	nodes := make([]*html.Node, 0, len(views))
+	for _, view := range views {
+		nodes = append(nodes, ctx.build(view))
+	}
+
+	allBlocks := true
+	for _, node := range nodes {
+		if node.Type == html.DocumentNode {
+			continue
+		}
+		allBlocks = allBlocks && isBlockNode(node)
+	}
+
+	for i, node := range nodes {
+		if i > 0 && allBlocks {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		if err := renderIndented(w, node, indent, 0); err != nil {
+			return err
+		}
+	}
+	return ctx.err()
+}
+
+func renderIndented(w io.Writer, node *html.Node, indent string, depth int) error {
+	switch {
+	case node.Type == html.DocumentNode:
+		// A document’s children are the doctype and <html>, where whitespace is ignored
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child != node.FirstChild {
+				if _, err := io.WriteString(w, "\n"); err != nil {
+					return err
+				}
+			}
+			if err := renderIndented(w, child, indent, depth); err != nil {
+				return err
+			}
+		}
+		return nil
+	case isBlockNode(node) && !whitespaceSensitiveAtoms[node.DataAtom] && hasOnlyBlockChildren(node):
+		// Only blocks are indented, as whitespace within an inline element such as <span> is displayed
+		startTag, endTag, err := renderTags(node)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, startTag); err != nil {
+			return err
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if _, err := io.WriteString(w, "\n"+strings.Repeat(indent, depth+1)); err != nil {
+				return err
+			}
+			if err := renderIndented(w, child, indent, depth+1); err != nil {
+				return err
+			}
+		}
+		_, err = io.WriteString(w, "\n"+strings.Repeat(indent, depth)+endTag)
+		return err
+	}
+	return html.Render(w, node)
+}
+
+// renderTags returns the start and end tags for node exactly as html.Render writes them
+func renderTags(node *html.Node) (string, string, error) {
+	shallow := &html.Node{
+		Type:      node.Type,
+		DataAtom:  node.DataAtom,
+		Data:      node.Data,
+		Namespace: node.Namespace,
+		Attr:      node.Attr,
+	}
+
+	b := new(bytes.Buffer)
+	if err := html.Render(b, shallow); err != nil {
+		return "", "", err
+	}
+	rendered := b.String()
+
+	endIndex := strings.LastIndex(rendered, "</")
+	return rendered[:endIndex], rendered[endIndex:], nil
+}
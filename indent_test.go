@@ -0,0 +1,147 @@
+package dovetail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html/atom"
+	"gotest.tools/assert"
+)
+
+func subjectAsIndentedString(views ...HTMLView) string {
+	b := new(bytes.Buffer)
+	RenderIndented(b, "  ", views...)
+	return b.String()
+}
+
+func TestRenderIndented(t *testing.T) {
+	readme := Div(
+		Header(
+			Nav(
+				AriaLabel("Primary"),
+				List(
+					Link("/", Text("Home")),
+					Link("/pricing", Text("Pricing"), AriaCurrentPage),
+				),
+			),
+		),
+		Main(
+			Article(
+				H(1, Text("Welcome")),
+				P(Text("Render "), TextWith("HTML"), Text(" with Go")),
+			),
+		),
+	)
+
+	t.Run("Rendering the Readme example", func(t *testing.T) {
+		s := subjectAsIndentedString(readme)
+
+		t.Run(`it puts block elements on their own lines, keeping inline content together`, func(t *testing.T) {
+			assert.Equal(t, s, `<div>
+  <header>
+    <nav aria-label="Primary">
+      <ul>
+        <li><a href="/">Home</a></li>
+        <li><a href="/pricing" aria-current="page">Pricing</a></li>
+      </ul>
+    </nav>
+  </header>
+  <main>
+    <article>
+      <h1>Welcome</h1>
+      <p>Render <span>HTML</span> with Go</p>
+    </article>
+  </main>
+</div>`)
+		})
+
+		t.Run(`it only differs from the compact output by whitespace between blocks`, func(t *testing.T) {
+			lines := strings.Split(s, "\n")
+			for i := range lines {
+				lines[i] = strings.TrimLeft(lines[i], " ")
+			}
+			assert.Equal(t, strings.Join(lines, ""), subjectAsString(readme))
+		})
+	})
+
+	t.Run("Rendering whitespace-sensitive content", func(t *testing.T) {
+		s := subjectAsIndentedString(Div(
+			HTMLElementViewOf("pre", atom.Pre, []HTMLView{Div(Text("  keep\n  as is"))}),
+			P(FieldLabelled("Notes", Textbox("notes").Rows(2).DefaultValue("line one\nline two"))),
+		))
+
+		t.Run(`it leaves <pre> and <textarea> intact`, func(t *testing.T) {
+			assert.Equal(t, s, "<div>\n  <pre><div>  keep\n  as is</div></pre>\n  <p><label><span>Notes</span><textarea name=\"notes\" rows=\"2\">line one\nline two</textarea></label></p>\n</div>")
+		})
+	})
+
+	t.Run("Rendering mixed block and inline children", func(t *testing.T) {
+		s := subjectAsIndentedString(Div(H(2, Text("Title")), Text("loose text")))
+
+		t.Run(`it keeps the children together`, func(t *testing.T) {
+			assert.Equal(t, s, `<div><h2>Title</h2>loose text</div>`)
+		})
+	})
+
+	t.Run("Rendering an inline element with block children", func(t *testing.T) {
+		s := subjectAsIndentedString(P(HTMLElementViewOf("span", atom.Span, []HTMLView{Div(Text("a")), Div(Text("b"))})))
+
+		t.Run(`it does not add whitespace within the inline element`, func(t *testing.T) {
+			assert.Equal(t, s, `<p><span><div>a</div><div>b</div></span></p>`)
+		})
+	})
+
+	t.Run("Rendering several inline views", func(t *testing.T) {
+		s := subjectAsIndentedString(Text("a"), TextWith("b"))
+
+		t.Run(`it does not add whitespace between them`, func(t *testing.T) {
+			assert.Equal(t, s, `a<span>b</span>`)
+		})
+	})
+
+	t.Run("Rendering a Document", func(t *testing.T) {
+		s := subjectAsIndentedString(Document(Head(Title("Hello")), Main(P(Text("Hi")))))
+
+		t.Run(`it indents the head and body`, func(t *testing.T) {
+			assert.Equal(t, s, `<!DOCTYPE html>
+<html>
+  <head>
+    <meta charset="utf-8"/>
+    <title>Hello</title>
+  </head>
+  <body>
+    <main>
+      <p>Hi</p>
+    </main>
+  </body>
+</html>`)
+		})
+	})
+
+	t.Run("Rendering with a context", func(t *testing.T) {
+		b := new(bytes.Buffer)
+		err := RenderIndentedContext(WithLocale(WithCSPNonce(context.Background(), "abc123"), "fr"), b, "  ", Div(InlineScript(`go()`), P(FormatNumber(1234.5))))
+
+		t.Run(`it uses the nonce and locale from the context`, func(t *testing.T) {
+			assert.NilError(t, err)
+			assert.Equal(t, b.String(), "<div>\n  <script nonce=\"abc123\">go()</script>\n  <p>1\u202f234,5</p>\n</div>")
+		})
+	})
+
+	t.Run("Rendering to a failing writer", func(t *testing.T) {
+		err := RenderIndented(failingWriter{}, "  ", Div(P(Text("Hi"))))
+
+		t.Run(`it returns the write error`, func(t *testing.T) {
+			assert.Error(t, err, "write failed")
+		})
+	})
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
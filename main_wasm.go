@@ -8,6 +8,7 @@ import (
 
 func main() {
 	view := Header(
+		Head(Title("Dovetail")),
 		Nav(
 			AriaLabel("Primary"),
 			List(
@@ -20,9 +21,15 @@ func main() {
 		),
 	)
 
-	b := new(bytes.Buffer)
-	Render(b, view)
-	rawHTML := b.String()
+	body := new(bytes.Buffer)
+	head := new(bytes.Buffer)
+	RenderWithHead(body, head, view)
 
-	js.Global().Call("updateBody", rawHTML)
+	js.Global().Call("updateHead", head.String())
+	js.Global().Call("updateBody", body.String())
+
+	listenForEnhancedEvents()
+
+	// Keep running so event listeners can be called
+	select {}
 }
@@ -0,0 +1,220 @@
+package dovetail
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+type markdownClasses struct {
+	tagName    string
+	classNames ClassNames
+}
+
+// MarkdownView renders Markdown as Dovetail elements, such as H, P, List and Link
+type MarkdownView struct {
+	source        string
+	headingOffset int
+	classes       []markdownClasses
+}
+
+// Markdown renders CommonMark source, including GitHub Flavored Markdown tables.
+// Any HTML within the source is escaped, so it is safe to use with content from users.
+func Markdown(source string) MarkdownView {
+	return MarkdownView{source: source}
+}
+
+// HeadingOffset shifts the level of headings, so a # heading renders as <h2> with an offset of 1.
+// Levels are kept between <h1> and <h6>.
+func (md MarkdownView) HeadingOffset(offset int) MarkdownView {
+	md.headingOffset = offset
+	return md
+}
+
+// ClassesFor adds class names to every element with the tag name, such as "h2" or "a"
+func (md MarkdownView) ClassesFor(tagName string, classNames ...string) MarkdownView {
+	md.classes = append(md.classes[:len(md.classes):len(md.classes)], markdownClasses{tagName: tagName, classNames: classNames})
+	return md
+}
+
+func (md MarkdownView) classNamesFor(tagName string) ClassNames {
+	var classNames ClassNames
+	for _, classes := range md.classes {
+		if classes.tagName == tagName {
+			classNames = classNames.Concat(classes.classNames)
+		}
+	}
+	return classNames
+}
+
+// element makes an element with the class names for its tag name
+func (md MarkdownView) element(tagName string, tagAtom atom.Atom, children []HTMLView) HTMLElementView {
+	return HTMLElementViewOf(tagName, tagAtom, children).AddClasses(md.classNamesFor(tagName))
+}
+
+// apply makes the node a fragment of the rendered blocks, which is spliced into the parent element
+func (md MarkdownView) apply(node *html.Node, ctx *buildContext) {
+	blocks, refs := parseMarkdown(md.source)
+
+	node.Type = html.DocumentNode
+	for _, view := range md.blockViews(blocks, refs, false) {
+		appendNode(node, ctx.build(view))
+	}
+}
+
+func (md MarkdownView) blockViews(blocks []*mdBlock, refs map[string]mdLinkRef, tight bool) []HTMLView {
+	views := make([]HTMLView, 0, len(blocks))
+	for _, block := range blocks {
+		views = append(views, md.blockView(block, refs, tight)...)
+	}
+	return views
+}
+
+func (md MarkdownView) blockView(block *mdBlock, refs map[string]mdLinkRef, tight bool) []HTMLView {
+	switch block.kind {
+	case mdParagraph:
+		inline := md.inlineViews(parseInline(block.text, refs))
+		if tight {
+			// Paragraphs in tight lists are not wrapped in <p>
+			return inline
+		}
+		return []HTMLView{md.element("p", atom.P, inline)}
+	case mdHeading:
+		level := block.level + md.headingOffset
+		if level > 6 {
+			level = 6
+		} else if level < 1 {
+			level = 1
+		}
+		children := md.inlineViews(parseInline(block.text, refs))
+		if classNames := md.classNamesFor("h" + strconv.Itoa(level)); len(classNames) > 0 {
+			children = append(children, HTMLClassNameView{classNames: classNames})
+		}
+		return []HTMLView{H(level, children...)}
+	case mdBlockquote:
+		return []HTMLView{md.element("blockquote", atom.Blockquote, md.blockViews(block.children, refs, false))}
+	case mdList:
+		items := make([]HTMLView, 0, len(block.children))
+		for _, item := range block.children {
+			items = append(items, md.element("li", atom.Li, md.blockViews(item.children, refs, block.tight)))
+		}
+		if !block.ordered {
+			return []HTMLView{md.element("ul", atom.Ul, items)}
+		}
+		if block.start != 1 {
+			items = append([]HTMLView{ListStart(block.start)}, items...)
+		}
+		return []HTMLView{md.element("ol", atom.Ol, items)}
+	case mdCodeBlock:
+		var codeChildren []HTMLView
+		if block.info != "" {
+			codeChildren = append(codeChildren, Class("language-"+block.info))
+		}
+		codeChildren = append(codeChildren, Text(block.text))
+		return []HTMLView{md.element("pre", atom.Pre, []HTMLView{md.element("code", atom.Code, codeChildren)})}
+	case mdThematicBreak:
+		return []HTMLView{md.element("hr", atom.Hr, nil)}
+	case mdTable:
+		header := md.element("thead", atom.Thead, []HTMLView{md.tableRowView(block.children[0], refs)})
+		children := []HTMLView{header}
+		if len(block.children) > 1 {
+			rows := make([]HTMLView, 0, len(block.children)-1)
+			for _, row := range block.children[1:] {
+				rows = append(rows, md.tableRowView(row, refs))
+			}
+			children = append(children, md.element("tbody", atom.Tbody, rows))
+		}
+		return []HTMLView{md.element("table", atom.Table, children)}
+	}
+	return nil
+}
+
+func (md MarkdownView) tableRowView(row *mdBlock, refs map[string]mdLinkRef) HTMLView {
+	cells := make([]HTMLView, 0, len(row.children))
+	for _, cell := range row.children {
+		children := md.inlineViews(parseInline(cell.text, refs))
+		if cell.align != "" {
+			// A class rather than a style attribute, which a Content-Security-Policy would block
+			children = append([]HTMLView{Class("text-" + cell.align)}, children...)
+		}
+		if cell.header {
+			cells = append(cells, md.element("th", atom.Th, children))
+		} else {
+			cells = append(cells, md.element("td", atom.Td, children))
+		}
+	}
+	return md.element("tr", atom.Tr, cells)
+}
+
+func (md MarkdownView) inlineViews(nodes []*mdInline) []HTMLView {
+	views := make([]HTMLView, 0, len(nodes))
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			views = append(views, Text(text.String()))
+			text.Reset()
+		}
+	}
+
+	for _, node := range nodes {
+		switch node.kind {
+		case mdText, mdBracket:
+			text.WriteString(node.text)
+		case mdDelimiter:
+			text.WriteString(strings.Repeat(string(node.delim), node.count))
+		case mdSoftBreak:
+			text.WriteString("\n")
+		default:
+			flush()
+			views = append(views, md.inlineView(node)...)
+		}
+	}
+	flush()
+	return views
+}
+
+func (md MarkdownView) inlineView(node *mdInline) []HTMLView {
+	switch node.kind {
+	case mdHardBreak:
+		return []HTMLView{md.element("br", atom.Br, nil), Text("\n")}
+	case mdCode:
+		return []HTMLView{md.element("code", atom.Code, []HTMLView{Text(node.text)})}
+	case mdEmphasis:
+		return []HTMLView{md.element("em", atom.Em, md.inlineViews(node.children))}
+	case mdStrong:
+		return []HTMLView{md.element("strong", atom.Strong, md.inlineViews(node.children))}
+	case mdLink:
+		children := md.inlineViews(node.children)
+		if node.title != "" {
+			children = append(children, CustomAttr("title", node.title))
+		}
+		return []HTMLView{Link(node.dest, children...).AddClasses(md.classNamesFor("a"))}
+	case mdImage:
+		img := Img(node.dest, plainInlineText(node.children))
+		if node.title != "" {
+			img = img.Use(CustomAttr("title", node.title))
+		}
+		return []HTMLView{img.AddClasses(md.classNamesFor("img"))}
+	}
+	return nil
+}
+
+// plainInlineText is the text of inline nodes without formatting, used for the alt text of images
+func plainInlineText(nodes []*mdInline) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		switch node.kind {
+		case mdText, mdBracket, mdCode:
+			b.WriteString(node.text)
+		case mdDelimiter:
+			b.WriteString(strings.Repeat(string(node.delim), node.count))
+		case mdSoftBreak, mdHardBreak:
+			b.WriteString(" ")
+		default:
+			b.WriteString(plainInlineText(node.children))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,502 @@
+package dovetail
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+type mdInlineKind int
+
+const (
+	mdText mdInlineKind = iota
+	mdSoftBreak
+	mdHardBreak
+	mdCode
+	mdEmphasis
+	mdStrong
+	mdLink
+	mdImage
+	mdDelimiter // a run of * or _ that may become emphasis
+	mdBracket   // a [ or ![ that may become a link or image
+)
+
+// mdInline is an inline node of parsed Markdown
+type mdInline struct {
+	kind     mdInlineKind
+	text     string
+	children []*mdInline
+	dest     string
+	title    string
+
+	// Delimiter runs
+	delim         byte
+	count         int
+	originalCount int
+	canOpen       bool
+	canClose      bool
+
+	// Brackets
+	image bool
+	start int // index in the source just after the bracket
+
+	// While processing emphasis, the nodes and delimiter runs are linked lists
+	order     int
+	prev      *mdInline
+	next      *mdInline
+	prevDelim *mdInline
+	nextDelim *mdInline
+}
+
+// mdMaxLinkLabel is the longest a link label can be, so long runs of brackets are not looked up over and over
+const mdMaxLinkLabel = 999
+
+// mdMaxLinkParens is the deepest parentheses can nest in a link destination
+const mdMaxLinkParens = 32
+
+var (
+	mdEntityRegexp        = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[a-zA-Z][a-zA-Z0-9]{1,31});`)
+	mdURIAutolinkRegexp   = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^<>\x00-\x20]*)>`)
+	mdEmailAutolinkRegexp = regexp.MustCompile(`^<([a-zA-Z0-9.!#$%&'*+/=?^_` + "`" + `{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*)>`)
+)
+
+type mdInlineParser struct {
+	source string
+	refs   map[string]mdLinkRef
+	nodes  []*mdInline
+	text   strings.Builder
+
+	brackets       []int        // indexes of the open brackets within nodes
+	inactiveBelow  int          // link brackets below this in the stack are within a link, so cannot make one
+	codeRunsMissed map[int]bool // backtick run lengths with no closing run left in the source
+}
+
+// parseInline parses the inline content of a paragraph, heading or table cell
+func parseInline(source string, refs map[string]mdLinkRef) []*mdInline {
+	p := &mdInlineParser{source: source, refs: refs, codeRunsMissed: make(map[int]bool)}
+	p.parse()
+	return processEmphasis(p.nodes)
+}
+
+func (p *mdInlineParser) flushText() {
+	if p.text.Len() > 0 {
+		p.nodes = append(p.nodes, &mdInline{kind: mdText, text: p.text.String()})
+		p.text.Reset()
+	}
+}
+
+func (p *mdInlineParser) push(node *mdInline) {
+	p.flushText()
+	p.nodes = append(p.nodes, node)
+}
+
+func (p *mdInlineParser) parse() {
+	s := p.source
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			p.push(&mdInline{kind: mdHardBreak})
+			i += 2
+			i += len(s[i:]) - len(strings.TrimLeft(s[i:], " "))
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			p.text.WriteByte(s[i+1])
+			i += 2
+		case c == '`':
+			i = p.parseCodeSpan(i)
+		case c == '*' || c == '_':
+			i = p.parseDelimiterRun(i)
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			p.pushBracket(&mdInline{kind: mdBracket, text: "![", image: true, start: i + 2})
+			i += 2
+		case c == '[':
+			p.pushBracket(&mdInline{kind: mdBracket, text: "[", start: i + 1})
+			i++
+		case c == ']':
+			i = p.parseCloseBracket(i)
+		case c == '<':
+			i = p.parseAutolink(i)
+		case c == '&':
+			if entity := mdEntityRegexp.FindString(s[i:]); entity != "" {
+				p.text.WriteString(html.UnescapeString(entity))
+				i += len(entity)
+			} else {
+				p.text.WriteByte(c)
+				i++
+			}
+		case c == '\n':
+			// Two or more trailing spaces make a hard break
+			text := p.text.String()
+			trimmed := strings.TrimRight(text, " ")
+			hard := len(text)-len(trimmed) >= 2
+			p.text.Reset()
+			p.text.WriteString(trimmed)
+			if hard {
+				p.push(&mdInline{kind: mdHardBreak})
+			} else {
+				p.push(&mdInline{kind: mdSoftBreak})
+			}
+			i++
+			i += len(s[i:]) - len(strings.TrimLeft(s[i:], " "))
+		default:
+			p.text.WriteByte(c)
+			i++
+		}
+	}
+	p.flushText()
+
+	// Unmatched brackets are just text
+	for _, index := range p.brackets {
+		p.nodes[index].kind = mdText
+	}
+}
+
+func (p *mdInlineParser) pushBracket(node *mdInline) {
+	p.push(node)
+	p.brackets = append(p.brackets, len(p.nodes)-1)
+}
+
+func (p *mdInlineParser) parseCodeSpan(i int) int {
+	s := p.source
+	run := 0
+	for i+run < len(s) && s[i+run] == '`' {
+		run++
+	}
+
+	// Find a closing run of exactly the same length
+	for j := i + run; j < len(s) && !p.codeRunsMissed[run]; {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+		closeRun := 0
+		for j+closeRun < len(s) && s[j+closeRun] == '`' {
+			closeRun++
+		}
+		if closeRun == run {
+			code := strings.Replace(s[i+run:j], "\n", " ", -1)
+			if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+				code = code[1 : len(code)-1]
+			}
+			p.push(&mdInline{kind: mdCode, text: code})
+			return j + closeRun
+		}
+		j += closeRun
+	}
+
+	p.codeRunsMissed[run] = true
+	p.text.WriteString(s[i : i+run])
+	return i + run
+}
+
+func isMarkdownPunct(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+func (p *mdInlineParser) parseDelimiterRun(i int) int {
+	s := p.source
+	c := s[i]
+	run := 0
+	for i+run < len(s) && s[i+run] == c {
+		run++
+	}
+
+	before := ' '
+	if i > 0 {
+		before, _ = utf8.DecodeLastRuneInString(s[:i])
+	}
+	after := ' '
+	if i+run < len(s) {
+		after, _ = utf8.DecodeRuneInString(s[i+run:])
+	}
+
+	leftFlanking := !unicode.IsSpace(after) && (!isMarkdownPunct(after) || unicode.IsSpace(before) || isMarkdownPunct(before))
+	rightFlanking := !unicode.IsSpace(before) && (!isMarkdownPunct(before) || unicode.IsSpace(after) || isMarkdownPunct(after))
+
+	node := &mdInline{kind: mdDelimiter, delim: c, count: run, originalCount: run}
+	if c == '*' {
+		node.canOpen = leftFlanking
+		node.canClose = rightFlanking
+	} else {
+		node.canOpen = leftFlanking && (!rightFlanking || isMarkdownPunct(before))
+		node.canClose = rightFlanking && (!leftFlanking || isMarkdownPunct(after))
+	}
+	p.push(node)
+	return i + run
+}
+
+func (p *mdInlineParser) parseAutolink(i int) int {
+	s := p.source
+	if match := mdURIAutolinkRegexp.FindStringSubmatch(s[i:]); match != nil {
+		p.push(&mdInline{kind: mdLink, dest: match[1], children: []*mdInline{{kind: mdText, text: match[1]}}})
+		return i + len(match[0])
+	}
+	if match := mdEmailAutolinkRegexp.FindStringSubmatch(s[i:]); match != nil {
+		p.push(&mdInline{kind: mdLink, dest: "mailto:" + match[1], children: []*mdInline{{kind: mdText, text: match[1]}}})
+		return i + len(match[0])
+	}
+	p.text.WriteByte('<')
+	return i + 1
+}
+
+func (p *mdInlineParser) parseCloseBracket(i int) int {
+	p.flushText()
+
+	if len(p.brackets) == 0 {
+		p.text.WriteByte(']')
+		return i + 1
+	}
+
+	top := len(p.brackets) - 1
+	openerIndex := p.brackets[top]
+	opener := p.nodes[openerIndex]
+	p.brackets = p.brackets[:top]
+	inactive := !opener.image && top < p.inactiveBelow
+	if p.inactiveBelow > top {
+		p.inactiveBelow = top
+	}
+	if inactive {
+		opener.kind = mdText
+		p.text.WriteByte(']')
+		return i + 1
+	}
+
+	dest, title, end, ok := p.parseLinkTail(i+1, p.source[opener.start:i])
+	if !ok {
+		opener.kind = mdText
+		p.text.WriteByte(']')
+		return i + 1
+	}
+
+	link := &mdInline{kind: mdLink, dest: dest, title: title, children: processEmphasis(p.nodes[openerIndex+1:])}
+	if opener.image {
+		link.kind = mdImage
+	}
+	p.nodes = append(p.nodes[:openerIndex:openerIndex], link)
+
+	// Links cannot contain other links
+	if !opener.image {
+		p.inactiveBelow = len(p.brackets)
+	}
+	return end
+}
+
+// parseLinkTail parses what follows ], either an inline (destination "title") or a reference to a definition
+func (p *mdInlineParser) parseLinkTail(i int, label string) (dest string, title string, end int, ok bool) {
+	s := p.source
+
+	if i < len(s) && s[i] == '(' {
+		if dest, title, end, ok := parseInlineLinkDest(s, i+1); ok {
+			return dest, title, end, true
+		}
+	}
+
+	if i < len(s) && s[i] == '[' {
+		if close := strings.IndexByte(s[i+1:], ']'); close != -1 {
+			refLabel := s[i+1 : i+1+close]
+			if strings.TrimSpace(refLabel) == "" {
+				refLabel = label
+			}
+			if ref, found := p.refs[mdNormalizeLabel(refLabel)]; found {
+				return ref.dest, ref.title, i + 1 + close + 1, true
+			}
+			return "", "", 0, false
+		}
+	}
+
+	// Shortcut reference
+	if len(label) > mdMaxLinkLabel {
+		return "", "", 0, false
+	}
+	if ref, found := p.refs[mdNormalizeLabel(label)]; found {
+		return ref.dest, ref.title, i, true
+	}
+	return "", "", 0, false
+}
+
+func skipMarkdownSpace(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n') {
+		i++
+	}
+	return i
+}
+
+func parseInlineLinkDest(s string, i int) (dest string, title string, end int, ok bool) {
+	i = skipMarkdownSpace(s, i)
+
+	if i < len(s) && s[i] == '<' {
+		close := strings.IndexAny(s[i+1:], "<>\n")
+		if close == -1 || s[i+1+close] != '>' {
+			return "", "", 0, false
+		}
+		dest = s[i+1 : i+1+close]
+		i = i + 1 + close + 1
+	} else {
+		start := i
+		depth := 0
+		for i < len(s) {
+			c := s[i]
+			if c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]) {
+				i += 2
+				continue
+			}
+			if c == '(' {
+				depth++
+				if depth > mdMaxLinkParens {
+					return "", "", 0, false
+				}
+			} else if c == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			} else if c == ' ' || c == '\t' || c == '\n' || c < 0x20 {
+				break
+			}
+			i++
+		}
+		dest = s[start:i]
+	}
+
+	afterDest := i
+	i = skipMarkdownSpace(s, i)
+	if i < len(s) && i > afterDest && (s[i] == '"' || s[i] == '\'' || s[i] == '(') {
+		closers := s[i : i+1]
+		if closers == "(" {
+			// Titles in parentheses cannot contain other parentheses
+			closers = "()"
+		}
+		close := strings.IndexAny(s[i+1:], closers)
+		if close == -1 || s[i+1+close] == '(' {
+			return "", "", 0, false
+		}
+		title = s[i+1 : i+1+close]
+		i = skipMarkdownSpace(s, i+1+close+1)
+	}
+
+	if i >= len(s) || s[i] != ')' {
+		return "", "", 0, false
+	}
+	return unescapeMarkdown(dest), unescapeMarkdown(title), i + 1, true
+}
+
+// processEmphasis matches delimiter runs into emphasis and strong emphasis, following the CommonMark algorithm
+func processEmphasis(nodes []*mdInline) []*mdInline {
+	// Link the nodes, and the delimiter runs among them, so matches can be made without copying
+	head := &mdInline{}
+	last, lastDelim := head, head
+	for i, node := range nodes {
+		node.order = i
+		node.prev, node.next = last, nil
+		last.next = node
+		last = node
+		if node.kind == mdDelimiter {
+			node.prevDelim, node.nextDelim = lastDelim, nil
+			lastDelim.nextDelim = node
+			lastDelim = node
+		}
+	}
+
+	// Once no opener is found for a closer, later closers of the same kind need not look below it again
+	var openersBottom [2][2][3]int
+
+	for closer := head.nextDelim; closer != nil; {
+		if !closer.canClose {
+			closer = closer.nextDelim
+			continue
+		}
+
+		bottom := &openersBottom[mdDelimIndex(closer.delim)][mdBoolIndex(closer.canOpen)][closer.originalCount%3]
+		var opener *mdInline
+		for candidate := closer.prevDelim; candidate != head && candidate.order >= *bottom; candidate = candidate.prevDelim {
+			if candidate.delim != closer.delim || !candidate.canOpen {
+				continue
+			}
+			// The rule of 3 stops runs like *foo**bar* matching across each other
+			if (candidate.canClose || closer.canOpen) &&
+				(candidate.originalCount+closer.originalCount)%3 == 0 &&
+				!(candidate.originalCount%3 == 0 && closer.originalCount%3 == 0) {
+				continue
+			}
+			opener = candidate
+			break
+		}
+
+		if opener == nil {
+			*bottom = closer.order
+			next := closer.nextDelim
+			if !closer.canOpen {
+				removeDelim(closer)
+			}
+			closer = next
+			continue
+		}
+
+		use := 1
+		kind := mdEmphasis
+		if opener.count >= 2 && closer.count >= 2 {
+			use = 2
+			kind = mdStrong
+		}
+		opener.count -= use
+		closer.count -= use
+
+		emphasis := &mdInline{kind: kind, order: opener.order, prev: opener, next: closer}
+		for node := opener.next; node != closer; node = node.next {
+			emphasis.children = append(emphasis.children, node)
+		}
+		opener.next = emphasis
+		closer.prev = emphasis
+		// Unmatched runs within the emphasis stay as text
+		opener.nextDelim = closer
+		closer.prevDelim = opener
+
+		if opener.count == 0 {
+			removeNode(opener)
+			removeDelim(opener)
+		}
+		if closer.count == 0 {
+			next := closer.nextDelim
+			removeNode(closer)
+			removeDelim(closer)
+			closer = next
+		}
+	}
+
+	processed := make([]*mdInline, 0, len(nodes))
+	for node := head.next; node != nil; node = node.next {
+		processed = append(processed, node)
+	}
+	return processed
+}
+
+func mdDelimIndex(delim byte) int {
+	if delim == '_' {
+		return 1
+	}
+	return 0
+}
+
+func mdBoolIndex(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func removeNode(node *mdInline) {
+	node.prev.next = node.next
+	if node.next != nil {
+		node.next.prev = node.prev
+	}
+}
+
+func removeDelim(delim *mdInline) {
+	delim.prevDelim.nextDelim = delim.nextDelim
+	if delim.nextDelim != nil {
+		delim.nextDelim.prevDelim = delim.prevDelim
+	}
+}
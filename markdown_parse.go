@@ -0,0 +1,677 @@
+package dovetail
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// The Markdown parser supports the commonly used parts of CommonMark, plus GitHub Flavored Markdown tables.
+// Raw HTML within Markdown is escaped and shown as text, so content from users stays safe.
+
+type mdKind int
+
+const (
+	mdParagraph mdKind = iota
+	mdHeading
+	mdBlockquote
+	mdList
+	mdListItem
+	mdCodeBlock
+	mdThematicBreak
+	mdTable
+	mdTableRow
+	mdTableCell
+	mdDocument
+)
+
+// mdMaxNesting is the deepest that blockquotes and lists can be nested, beyond which markers are shown as text
+const mdMaxNesting = 32
+
+// mdBlock is a block-level node of parsed Markdown
+type mdBlock struct {
+	kind     mdKind
+	children []*mdBlock
+	text     string // raw inline content of paragraphs, headings and cells, or the content of code blocks
+	level    int    // heading level
+	info     string // code block language
+	ordered  bool
+	start    int
+	tight    bool
+	header   bool
+	align    string
+
+	// State while parsing
+	parent        *mdBlock
+	lines         []string // content of open paragraphs and code blocks
+	marker        byte     // the bullet or ordered delimiter of lists
+	contentIndent int      // the indent of a list item’s content
+	startsBlank   bool     // whether a list item’s first line has no content
+	fence         string   // the opening fence of fenced code, empty for indented code
+	fenceIndent   int
+	aligns        []string // the alignment of table columns
+	lastLineBlank bool
+}
+
+func (block *mdBlock) isContainer() bool {
+	return block.kind == mdBlockquote || block.kind == mdList || block.kind == mdListItem
+}
+
+func (block *mdBlock) lastChild() *mdBlock {
+	if len(block.children) == 0 {
+		return nil
+	}
+	return block.children[len(block.children)-1]
+}
+
+type mdLinkRef struct {
+	dest  string
+	title string
+}
+
+// mdParser parses blocks a line at a time, keeping the open blocks from the document to the innermost as a stack
+type mdParser struct {
+	refs map[string]mdLinkRef
+	open []*mdBlock
+}
+
+var (
+	mdATXHeadingRegexp    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*))?$`)
+	mdThematicBreakRegexp = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdFenceRegexp         = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})(.*)$")
+	mdBlockquoteRegexp    = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	mdListItemRegexp      = regexp.MustCompile(`^( {0,3})([-+*]|[0-9]{1,9}[.)])(?:([ \t]+)(.*))?$`)
+	mdSetextRegexp        = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	mdTableDelimRegexp    = regexp.MustCompile(`^[ \t]*\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	mdLinkRefRegexp       = regexp.MustCompile(`^ {0,3}\[([^\]]+)\]:[ \t]*<?([^\s>]+)>?(?:[ \t]+(?:"([^"]*)"|'([^']*)'|\(([^)]*)\)))?[ \t]*$`)
+)
+
+// expandTabs replaces tabs in the leading whitespace of line with spaces, using tab stops of 4
+func expandTabs(line string) string {
+	var b strings.Builder
+	column := 0
+	for i, r := range line {
+		switch r {
+		case ' ':
+			b.WriteByte(' ')
+			column++
+		case '\t':
+			spaces := 4 - column%4
+			b.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+		default:
+			b.WriteString(line[i:])
+			return b.String()
+		}
+	}
+	return b.String()
+}
+
+func mdIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func mdIsBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func mdNormalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+// parseMarkdown parses source into blocks, and collects link reference definitions
+func parseMarkdown(source string) ([]*mdBlock, map[string]mdLinkRef) {
+	source = strings.Replace(source, "\r\n", "\n", -1)
+	source = strings.Replace(source, "\r", "\n", -1)
+
+	lines := strings.Split(source, "\n")
+	for i, line := range lines {
+		lines[i] = expandTabs(line)
+	}
+	// A final newline ends the last line rather than adding a blank one
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	root := &mdBlock{kind: mdDocument}
+	parser := &mdParser{refs: make(map[string]mdLinkRef), open: []*mdBlock{root}}
+	for _, line := range lines {
+		parser.addLine(line)
+	}
+	parser.closeTo(0)
+	return root.children, parser.refs
+}
+
+// interruptsParagraph reports whether line starts a block that ends a paragraph in progress
+func interruptsParagraph(line string) bool {
+	if mdIsBlank(line) || mdATXHeadingRegexp.MatchString(line) || mdThematicBreakRegexp.MatchString(line) || mdBlockquoteRegexp.MatchString(line) {
+		return true
+	}
+	if match := mdFenceRegexp.FindStringSubmatch(line); match != nil && !(match[2][0] == '`' && strings.Contains(match[3], "`")) {
+		return true
+	}
+	if match := mdListItemRegexp.FindStringSubmatch(line); match != nil && strings.TrimSpace(match[4]) != "" {
+		marker := match[2]
+		return !isOrderedMarker(marker) || marker[:len(marker)-1] == "1"
+	}
+	return false
+}
+
+func isOrderedMarker(marker string) bool {
+	return marker[0] >= '0' && marker[0] <= '9'
+}
+
+func (p *mdParser) tip() *mdBlock {
+	return p.open[len(p.open)-1]
+}
+
+// add appends block to the innermost open block, and opens it unless it is a heading or thematic break
+func (p *mdParser) add(block *mdBlock) {
+	parent := p.tip()
+	block.parent = parent
+	parent.children = append(parent.children, block)
+	if block.kind != mdHeading && block.kind != mdThematicBreak {
+		p.open = append(p.open, block)
+	}
+}
+
+// closeTip closes the innermost open block
+func (p *mdParser) closeTip() {
+	block := p.tip()
+	p.open = p.open[:len(p.open)-1]
+	p.finalize(block)
+}
+
+// closeTo closes open blocks until only n remain
+func (p *mdParser) closeTo(n int) {
+	for len(p.open) > n {
+		p.closeTip()
+	}
+}
+
+// setLastLineBlank records whether the line ended with a blank, for block and every block containing it
+func setLastLineBlank(block *mdBlock, blank bool) {
+	for ; block != nil; block = block.parent {
+		block.lastLineBlank = blank
+	}
+}
+
+// continues reports whether an open container continues on line, returning the rest of the line within it
+func (p *mdParser) continues(block *mdBlock, line string) (string, bool) {
+	switch block.kind {
+	case mdBlockquote:
+		indent := mdIndent(line)
+		if indent <= 3 && indent < len(line) && line[indent] == '>' {
+			return strings.TrimPrefix(line[indent+1:], " "), true
+		}
+		return line, false
+	case mdListItem:
+		if mdIsBlank(line) {
+			// An item starting with a blank line can only have one
+			if block.startsBlank && len(block.children) == 0 {
+				return line, false
+			}
+			return "", true
+		}
+		if mdIndent(line) >= block.contentIndent {
+			return line[block.contentIndent:], true
+		}
+		return line, false
+	}
+	// Lists continue until an item does not
+	return line, true
+}
+
+// mdListMarker is the start of a list item
+type mdListMarker struct {
+	ordered bool
+	start   int
+	delim   byte
+	indent  int
+	width   int
+	spaces  int
+	content string
+}
+
+// parseListMarker parses a bullet such as - or * or an ordered marker such as 1. or 2) at the start of line
+func parseListMarker(line string) (mdListMarker, bool) {
+	marker := mdListMarker{indent: mdIndent(line)}
+	i := marker.indent
+	if i >= len(line) || marker.indent > 3 {
+		return marker, false
+	}
+
+	switch c := line[i]; {
+	case c == '-' || c == '+' || c == '*':
+		marker.delim = c
+		marker.width = 1
+	case c >= '0' && c <= '9':
+		digits := 0
+		for i+digits < len(line) && line[i+digits] >= '0' && line[i+digits] <= '9' {
+			digits++
+		}
+		if digits > 9 || i+digits >= len(line) || (line[i+digits] != '.' && line[i+digits] != ')') {
+			return marker, false
+		}
+		marker.ordered = true
+		marker.start, _ = strconv.Atoi(line[i : i+digits])
+		marker.delim = line[i+digits]
+		marker.width = digits + 1
+	default:
+		return marker, false
+	}
+
+	after := i + marker.width
+	if after < len(line) && line[after] != ' ' && line[after] != '\t' {
+		return marker, false
+	}
+	for after+marker.spaces < len(line) && (line[after+marker.spaces] == ' ' || line[after+marker.spaces] == '\t') {
+		marker.spaces++
+	}
+	marker.content = line[after+marker.spaces:]
+	return marker, true
+}
+
+// addLine adds a line to the open blocks: it continues the open containers it matches,
+// opens any new blockquotes and list items, then adds the rest to a paragraph, code block or table
+func (p *mdParser) addLine(line string) {
+	rest := line
+	matched := 1
+	for matched < len(p.open) && p.open[matched].isContainer() {
+		next, ok := p.continues(p.open[matched], rest)
+		if !ok {
+			break
+		}
+		rest = next
+		matched++
+	}
+
+	var leaf *mdBlock
+	if tip := p.tip(); tip.kind != mdDocument && !tip.isContainer() {
+		leaf = tip
+	}
+	allMatched := matched == len(p.open) || (leaf != nil && matched == len(p.open)-1)
+
+	// Fenced code takes every line until its closing fence
+	if leaf != nil && leaf.kind == mdCodeBlock && leaf.fence != "" && allMatched {
+		if isClosingFence(rest, leaf.fence) {
+			p.closeTip()
+		} else {
+			leaf.lines = append(leaf.lines, stripIndent(rest, leaf.fenceIndent))
+		}
+		setLastLineBlank(leaf, false)
+		return
+	}
+
+	paragraphOpen := leaf != nil && leaf.kind == mdParagraph
+	opened := false
+	for mdIndent(rest) < 4 && matched <= mdMaxNesting {
+		indent := mdIndent(rest)
+		if indent < len(rest) && rest[indent] == '>' {
+			matched = p.openContainer(&mdBlock{kind: mdBlockquote}, matched)
+			rest = strings.TrimPrefix(rest[indent+1:], " ")
+			opened = true
+			continue
+		}
+
+		if mdThematicBreakRegexp.MatchString(rest) {
+			break
+		}
+		marker, ok := parseListMarker(rest)
+		if !ok {
+			break
+		}
+		blank := mdIsBlank(marker.content)
+		if paragraphOpen && allMatched && !opened && (blank || (marker.ordered && marker.start != 1)) {
+			// Only items with content, and ordered lists starting at 1, can interrupt a paragraph
+			break
+		}
+
+		p.closeTo(matched)
+		if list := p.tip(); list.kind == mdList && (list.ordered != marker.ordered || list.marker != marker.delim) {
+			p.closeTip()
+		}
+		if p.tip().kind != mdList {
+			p.add(&mdBlock{kind: mdList, ordered: marker.ordered, start: marker.start, marker: marker.delim, tight: true})
+		}
+
+		// Content starts after the marker and up to 4 spaces, otherwise 1 space and the rest is indented code
+		item := &mdBlock{kind: mdListItem, contentIndent: marker.indent + marker.width + marker.spaces, startsBlank: blank}
+		rest = marker.content
+		if blank {
+			item.contentIndent = marker.indent + marker.width + 1
+			rest = ""
+		} else if marker.spaces > 4 {
+			item.contentIndent = marker.indent + marker.width + 1
+			rest = strings.Repeat(" ", marker.spaces-1) + marker.content
+		}
+		p.add(item)
+		matched = len(p.open)
+		opened = true
+	}
+
+	blank := mdIsBlank(rest)
+
+	// A lazy continuation line adds to the paragraph without the markers of its containers
+	if paragraphOpen && !allMatched && !opened && !blank && !interruptsParagraph(rest) {
+		leaf.lines = append(leaf.lines, strings.TrimLeft(rest, " \t"))
+		setLastLineBlank(leaf, false)
+		return
+	}
+
+	if opened || !allMatched {
+		p.closeTo(matched)
+		leaf = nil
+	}
+
+	if blank {
+		if last := p.open[matched-1].lastChild(); last != nil {
+			last.lastLineBlank = true
+		}
+		if leaf != nil {
+			if leaf.kind == mdCodeBlock {
+				leaf.lines = append(leaf.lines, stripIndent(rest, 4))
+			} else {
+				p.closeTip()
+			}
+		}
+		container := p.tip()
+		setLastLineBlank(container, container.kind != mdBlockquote && !(container.kind == mdListItem && opened && len(container.children) == 0))
+		return
+	}
+
+	if leaf != nil {
+		indent := mdIndent(rest)
+		switch leaf.kind {
+		case mdCodeBlock:
+			if indent >= 4 {
+				leaf.lines = append(leaf.lines, rest[4:])
+				setLastLineBlank(leaf, false)
+				return
+			}
+		case mdParagraph:
+			if indent < 4 {
+				if match := mdSetextRegexp.FindStringSubmatch(rest); match != nil && p.paragraphToHeading(leaf, match[1]) {
+					return
+				}
+				if p.paragraphToTable(leaf, rest) {
+					return
+				}
+			}
+			if indent >= 4 || !interruptsParagraph(rest) {
+				leaf.lines = append(leaf.lines, strings.TrimLeft(rest, " \t"))
+				setLastLineBlank(leaf, false)
+				return
+			}
+		case mdTable:
+			if !interruptsParagraph(rest) {
+				p.addTableRow(leaf, splitTableRow(rest), false)
+				setLastLineBlank(leaf, false)
+				return
+			}
+		}
+		p.closeTip()
+	}
+
+	p.startLeaf(rest)
+}
+
+// openContainer closes the blocks that did not continue, then opens block within the innermost one that did
+func (p *mdParser) openContainer(block *mdBlock, matched int) int {
+	p.closeTo(matched)
+	if p.tip().kind == mdList {
+		p.closeTip()
+	}
+	p.add(block)
+	return len(p.open)
+}
+
+// startLeaf starts a heading, thematic break, code block or paragraph with line
+func (p *mdParser) startLeaf(line string) {
+	if p.tip().kind == mdList {
+		p.closeTip()
+	}
+
+	var block *mdBlock
+	if mdIndent(line) >= 4 {
+		block = &mdBlock{kind: mdCodeBlock, lines: []string{line[4:]}}
+	} else if match := mdFenceRegexp.FindStringSubmatch(line); match != nil && !(match[2][0] == '`' && strings.Contains(match[3], "`")) {
+		block = &mdBlock{kind: mdCodeBlock, fence: match[2], fenceIndent: len(match[1])}
+		if fields := strings.Fields(unescapeMarkdown(match[3])); len(fields) > 0 {
+			block.info = fields[0]
+		}
+	} else if match := mdATXHeadingRegexp.FindStringSubmatch(line); match != nil {
+		block = &mdBlock{kind: mdHeading, level: len(match[1]), text: trimClosingHashes(match[2])}
+	} else if mdThematicBreakRegexp.MatchString(line) {
+		block = &mdBlock{kind: mdThematicBreak}
+	} else {
+		block = &mdBlock{kind: mdParagraph, lines: []string{strings.TrimLeft(line, " \t")}}
+	}
+	p.add(block)
+	setLastLineBlank(block, false)
+}
+
+func trimClosingHashes(content string) string {
+	content = strings.TrimSpace(content)
+	trimmed := strings.TrimRight(content, "#")
+	if trimmed == "" {
+		return ""
+	}
+	if len(trimmed) < len(content) && (strings.HasSuffix(trimmed, " ") || strings.HasSuffix(trimmed, "\t")) {
+		return strings.TrimSpace(trimmed)
+	}
+	return content
+}
+
+// paragraphToHeading makes the open paragraph a setext heading underlined by = or -
+func (p *mdParser) paragraphToHeading(paragraph *mdBlock, underline string) bool {
+	paragraph.lines = p.extractLinkRefs(paragraph.lines)
+	if len(paragraph.lines) == 0 {
+		return false
+	}
+
+	paragraph.kind = mdHeading
+	paragraph.level = 1
+	if underline[0] == '-' {
+		paragraph.level = 2
+	}
+	paragraph.text = strings.TrimRight(strings.Join(paragraph.lines, "\n"), " \t")
+	paragraph.lines = nil
+	p.open = p.open[:len(p.open)-1]
+	setLastLineBlank(paragraph, false)
+	return true
+}
+
+// paragraphToTable starts a table when line is a delimiter row, using the paragraph’s last line as the header row
+func (p *mdParser) paragraphToTable(paragraph *mdBlock, line string) bool {
+	if len(paragraph.lines) == 0 || !mdTableDelimRegexp.MatchString(line) {
+		return false
+	}
+	header := paragraph.lines[len(paragraph.lines)-1]
+	if !strings.Contains(header, "|") {
+		return false
+	}
+	headerCells, delims := splitTableRow(header), splitTableRow(line)
+	if len(headerCells) != len(delims) {
+		return false
+	}
+
+	paragraph.lines = paragraph.lines[:len(paragraph.lines)-1]
+	p.closeTip()
+
+	table := &mdBlock{kind: mdTable}
+	for _, delim := range delims {
+		left := strings.HasPrefix(delim, ":")
+		right := strings.HasSuffix(delim, ":")
+		switch {
+		case left && right:
+			table.aligns = append(table.aligns, "center")
+		case right:
+			table.aligns = append(table.aligns, "right")
+		case left:
+			table.aligns = append(table.aligns, "left")
+		default:
+			table.aligns = append(table.aligns, "")
+		}
+	}
+	p.add(table)
+	p.addTableRow(table, headerCells, true)
+	setLastLineBlank(table, false)
+	return true
+}
+
+// addTableRow adds a row with a cell for each column, ignoring extra cells
+func (p *mdParser) addTableRow(table *mdBlock, cells []string, header bool) {
+	row := &mdBlock{kind: mdTableRow, header: header}
+	for column, align := range table.aligns {
+		text := ""
+		if column < len(cells) {
+			text = cells[column]
+		}
+		row.children = append(row.children, &mdBlock{kind: mdTableCell, text: text, header: header, align: align})
+	}
+	table.children = append(table.children, row)
+}
+
+// extractLinkRefs collects the link reference definitions at the start of a paragraph, returning the lines left
+func (p *mdParser) extractLinkRefs(lines []string) []string {
+	for len(lines) > 0 {
+		match := mdLinkRefRegexp.FindStringSubmatch(lines[0])
+		if match == nil {
+			break
+		}
+		label := mdNormalizeLabel(match[1])
+		if _, exists := p.refs[label]; !exists {
+			p.refs[label] = mdLinkRef{dest: unescapeMarkdown(match[2]), title: unescapeMarkdown(match[3] + match[4] + match[5])}
+		}
+		lines = lines[1:]
+	}
+	return lines
+}
+
+// finalize completes a block once closed
+func (p *mdParser) finalize(block *mdBlock) {
+	switch block.kind {
+	case mdParagraph:
+		lines := p.extractLinkRefs(block.lines)
+		if len(lines) == 0 {
+			// A paragraph of only link reference definitions is not shown
+			parent := block.parent
+			parent.children = parent.children[:len(parent.children)-1]
+			return
+		}
+		block.text = strings.TrimRight(strings.Join(lines, "\n"), " \t")
+	case mdCodeBlock:
+		lines := block.lines
+		if block.fence == "" {
+			// Trailing blank lines are not part of indented code
+			for len(lines) > 0 && mdIsBlank(lines[len(lines)-1]) {
+				lines = lines[:len(lines)-1]
+			}
+		}
+		block.text = strings.Join(lines, "\n")
+		if len(lines) > 0 {
+			block.text += "\n"
+		}
+	case mdList:
+		block.tight = listIsTight(block)
+	}
+	block.lines = nil
+}
+
+// endsWithBlankLine reports whether a block, or the last of any lists and items within it, ended with a blank line
+func endsWithBlankLine(block *mdBlock) bool {
+	for block != nil {
+		if block.lastLineBlank {
+			return true
+		}
+		if block.kind != mdList && block.kind != mdListItem {
+			return false
+		}
+		block = block.lastChild()
+	}
+	return false
+}
+
+// listIsTight reports whether no items, or blocks within items, are separated by blank lines
+func listIsTight(list *mdBlock) bool {
+	for i, item := range list.children {
+		lastItem := i == len(list.children)-1
+		if !lastItem && endsWithBlankLine(item) {
+			return false
+		}
+		for j, child := range item.children {
+			if (!lastItem || j < len(item.children)-1) && endsWithBlankLine(child) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isClosingFence reports whether line closes fenced code opened with fence
+func isClosingFence(line string, fence string) bool {
+	indent := mdIndent(line)
+	if indent > 3 {
+		return false
+	}
+	run := 0
+	for indent+run < len(line) && line[indent+run] == fence[0] {
+		run++
+	}
+	return run >= len(fence) && strings.TrimRight(line[indent+run:], " \t") == ""
+}
+
+// stripIndent removes up to n leading spaces
+func stripIndent(line string, n int) string {
+	indent := mdIndent(line)
+	if indent > n {
+		indent = n
+	}
+	return line[indent:]
+}
+
+// splitTableRow splits a table row into its cells, respecting escaped pipes
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// unescapeMarkdown removes backslash escapes and decodes entities
+func unescapeMarkdown(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]) {
+			b.WriteByte(s[i+1])
+			i++
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return html.UnescapeString(b.String())
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
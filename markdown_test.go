@@ -0,0 +1,104 @@
+package dovetail
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestMarkdown(t *testing.T) {
+	t.Run("Rendering headings and inline formatting", func(t *testing.T) {
+		s := subjectAsString(Markdown("# Hello *world*\n\nSome **bold** text with `code` and [a link](https://example.org \"Title\").\n"))
+
+		t.Run(`it renders headings, paragraphs, emphasis, code and links`, func(t *testing.T) {
+			assert.Equal(t, s, `<h1>Hello <em>world</em></h1><p>Some <strong>bold</strong> text with <code>code</code> and <a href="https://example.org" title="Title">a link</a>.</p>`)
+		})
+	})
+
+	t.Run("Rendering lists", func(t *testing.T) {
+		s := subjectAsString(Markdown("- one\n- two\n\n3. three\n\n   more\n4. four\n"))
+
+		t.Run(`it renders tight lists without paragraphs`, func(t *testing.T) {
+			assert.Equal(t, s, `<ul><li>one</li><li>two</li></ul><ol start="3"><li><p>three</p><p>more</p></li><li><p>four</p></li></ol>`)
+		})
+	})
+
+	t.Run("Rendering fenced code blocks", func(t *testing.T) {
+		s := subjectAsString(Markdown("```go\nfmt.Println(1 < 2)\n```\n"))
+
+		t.Run(`it renders <pre><code> with the language class`, func(t *testing.T) {
+			assert.Equal(t, s, "<pre><code class=\"language-go\">fmt.Println(1 &lt; 2)\n</code></pre>")
+		})
+	})
+
+	t.Run("Rendering tables", func(t *testing.T) {
+		s := subjectAsString(Markdown("| Name | Count |\n|:-----|------:|\n| Apples | 2 |\n"))
+
+		t.Run(`it renders <thead> and <tbody> with alignment classes`, func(t *testing.T) {
+			assert.Equal(t, s, `<table><thead><tr><th class="text-left">Name</th><th class="text-right">Count</th></tr></thead><tbody><tr><td class="text-left">Apples</td><td class="text-right">2</td></tr></tbody></table>`)
+		})
+	})
+
+	t.Run("Rendering images, breaks, rules and reference links", func(t *testing.T) {
+		s := subjectAsString(Markdown("![A *cat*](/cat.png) sits  \nthere\n\n***\n[docs]\n\n[docs]: /docs"))
+
+		t.Run(`it renders each as elements`, func(t *testing.T) {
+			assert.Equal(t, s, "<p><img src=\"/cat.png\" alt=\"A cat\"/> sits<br/>\nthere</p><hr/><p><a href=\"/docs\">docs</a></p>")
+		})
+	})
+
+	t.Run("Rendering HTML within Markdown", func(t *testing.T) {
+		s := subjectAsString(Markdown("<script>alert(1)</script>\n\n> *quoted*\n"))
+
+		t.Run(`it escapes the HTML`, func(t *testing.T) {
+			assert.Equal(t, s, `<p>&lt;script&gt;alert(1)&lt;/script&gt;</p><blockquote><p><em>quoted</em></p></blockquote>`)
+		})
+	})
+
+	t.Run("Rendering within an element with heading offset and class hooks", func(t *testing.T) {
+		s := subjectAsString(Article(Markdown("## Notes\n\nSee [more](/more)").HeadingOffset(1).ClassesFor("h3", "title").TailwindFor("a", Pt1)))
+
+		t.Run(`it splices the elements into the parent and adds the classes`, func(t *testing.T) {
+			assert.Equal(t, s, `<article><h3 class="title">Notes</h3><p>See <a href="/more" class="pt-1">more</a></p></article>`)
+		})
+	})
+
+	t.Run("Rendering with a negative heading offset", func(t *testing.T) {
+		s := subjectAsString(Markdown("# Title\n\n### Section").HeadingOffset(-1))
+
+		t.Run(`it keeps headings at <h1> or below`, func(t *testing.T) {
+			assert.Equal(t, s, `<h1>Title</h1><h2>Section</h2>`)
+		})
+	})
+
+	t.Run("Rendering deeply nested and unbalanced source", func(t *testing.T) {
+		var nested strings.Builder
+		for i := 0; nested.Len() < 20000; i++ {
+			nested.WriteString(strings.Repeat("  ", i) + "- x\n")
+		}
+		sources := map[string]string{
+			"nested lists":       nested.String(),
+			"nested blockquotes": strings.Repeat("> ", 10000) + "x",
+			"emphasis":           strings.Repeat("**a", 10000) + strings.Repeat("b**", 10000),
+			"links":              strings.Repeat("[", 20000) + "a" + strings.Repeat("](b)", 10000),
+			"link destinations":  strings.Repeat("[a](", 10000),
+		}
+
+		for name, source := range sources {
+			t.Run(`it renders `+name+` in linear time`, func(t *testing.T) {
+				start := time.Now()
+				subjectAsString(Markdown(source))
+				assert.Assert(t, time.Since(start) < time.Second, "took %v", time.Since(start))
+			})
+		}
+
+		t.Run(`it shows markers beyond the nesting limit as text`, func(t *testing.T) {
+			s := subjectAsString(Markdown(strings.Repeat("> ", mdMaxNesting+1) + "x"))
+
+			assert.Equal(t, strings.Count(s, "<blockquote>"), mdMaxNesting)
+			assert.Assert(t, strings.Contains(s, "<p>&gt; x</p>"))
+		})
+	})
+}
@@ -0,0 +1,73 @@
+package dovetail
+
+import (
+	"golang.org/x/net/html/atom"
+)
+
+// Video makes <video>, usually with MediaSource and Track children, and fallback content for browsers that can’t play it
+func Video(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("video", atom.Video, children)
+}
+
+// Audio makes <audio>, usually with MediaSource and Track children
+func Audio(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("audio", atom.Audio, children)
+}
+
+// MediaSource makes <source> for Video or Audio, with the media’s URL and MIME type such as "video/webm"
+func MediaSource(srcURL string, mimeType string) HTMLElementView {
+	return HTMLElementViewOf("source", atom.Source, []HTMLView{CustomAttr("src", srcURL), CustomAttr("type", mimeType)})
+}
+
+// Track makes <track> for Video or Audio, where kind is such as "captions", "subtitles" or "descriptions",
+// srclang is the language of the track such as "en", and label is shown to the user when choosing tracks
+func Track(kind string, srcURL string, srclang string, label string) HTMLElementView {
+	return HTMLElementViewOf("track", atom.Track, []HTMLView{
+		CustomAttr("kind", kind),
+		CustomAttr("src", srcURL),
+		CustomAttr("srclang", srclang),
+		CustomAttr("label", label),
+	})
+}
+
+// Captions makes <track kind="captions">, a transcription of the dialogue and sounds for viewers who can’t hear it
+func Captions(srcURL string, srclang string, label string) HTMLElementView {
+	return Track("captions", srcURL, srclang, label)
+}
+
+// Subtitles makes <track kind="subtitles">, a translation of the dialogue
+func Subtitles(srcURL string, srclang string, label string) HTMLElementView {
+	return Track("subtitles", srcURL, srclang, label)
+}
+
+// DefaultTrack enables the Track unless the user prefers another
+var DefaultTrack = HTMLAttrView{Key: "default"}
+
+// Controls shows the browser’s controls for Video and Audio
+var Controls = HTMLAttrView{Key: "controls"}
+
+// Autoplay starts playing Video or Audio as soon as it can. Browsers usually only allow this when Muted.
+var Autoplay = HTMLAttrView{Key: "autoplay"}
+
+// Muted starts Video or Audio without sound
+var Muted = HTMLAttrView{Key: "muted"}
+
+// Loop plays Video or Audio again when it finishes
+var Loop = HTMLAttrView{Key: "loop"}
+
+// PlaysInline plays Video within the page on mobile browsers, instead of fullscreen
+var PlaysInline = HTMLAttrView{Key: "playsinline"}
+
+// Poster shows an image until Video starts playing
+func Poster(imageURL string) HTMLAttrView {
+	return HTMLAttrView{Key: "poster", Value: imageURL}
+}
+
+// Figure makes <figure> with the content followed by <figcaption> with the caption
+func Figure(content HTMLView, caption HTMLView) HTMLElementView {
+	return HTMLElementViewOf("figure", atom.Figure, []HTMLView{content, Figcaption(caption)})
+}
+
+func Figcaption(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("figcaption", atom.Figcaption, children)
+}
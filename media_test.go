@@ -0,0 +1,45 @@
+package dovetail
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestMedia(t *testing.T) {
+	t.Run("Rendering Video with sources, tracks and options", func(t *testing.T) {
+		s := subjectAsString(Video(
+			MediaSource("/intro.webm", "video/webm"),
+			MediaSource("/intro.mp4", "video/mp4"),
+			Captions("/intro.en.vtt", "en", "English").Use(DefaultTrack),
+			Subtitles("/intro.fr.vtt", "fr", "Français"),
+			Link("/intro.mp4", Text("Download the video")),
+		).Use(Controls, Muted, Autoplay, PlaysInline, Loop, Poster("/intro.jpg")))
+
+		t.Run(`it renders <video> with <source> and <track> children`, func(t *testing.T) {
+			assert.Equal(t, s, `<video controls="" muted="" autoplay="" playsinline="" loop="" poster="/intro.jpg">`+
+				`<source src="/intro.webm" type="video/webm"/>`+
+				`<source src="/intro.mp4" type="video/mp4"/>`+
+				`<track kind="captions" src="/intro.en.vtt" srclang="en" label="English" default=""/>`+
+				`<track kind="subtitles" src="/intro.fr.vtt" srclang="fr" label="Français"/>`+
+				`<a href="/intro.mp4">Download the video</a>`+
+				`</video>`)
+		})
+	})
+
+	t.Run("Rendering Audio", func(t *testing.T) {
+		s := subjectAsString(Audio(MediaSource("/episode.mp3", "audio/mpeg"), Track("descriptions", "/episode.vtt", "en", "Chapters")).Use(Controls))
+
+		t.Run(`it renders <audio>`, func(t *testing.T) {
+			assert.Equal(t, s, `<audio controls=""><source src="/episode.mp3" type="audio/mpeg"/><track kind="descriptions" src="/episode.vtt" srclang="en" label="Chapters"/></audio>`)
+		})
+	})
+
+	t.Run("Rendering Figure", func(t *testing.T) {
+		s := subjectAsString(Figure(Img("/chart.png", "Sales by month"), Text("Sales doubled in March")))
+
+		t.Run(`it renders <figure> with the content then <figcaption>`, func(t *testing.T) {
+			assert.Equal(t, s, `<figure><img src="/chart.png" alt="Sales by month"/><figcaption>Sales doubled in March</figcaption></figure>`)
+		})
+	})
+}
@@ -0,0 +1,136 @@
+package dovetail
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// NavItem is a link within NavMenu, with an optional submenu
+type NavItem struct {
+	label    string
+	url      string
+	children []NavItem
+}
+
+// MenuItem makes an item for NavMenu linking to url, with children making a nested submenu
+func MenuItem(label string, url string, children ...NavItem) NavItem {
+	return NavItem{label: label, url: url, children: children}
+}
+
+// NavMenuView makes navigation links, marking the current page and its ancestors
+type NavMenuView struct {
+	currentPath     string
+	items           []NavItem
+	label           string
+	activeClasses   ClassNames
+	inactiveClasses ClassNames
+	elementCore     HTMLElementCore
+}
+
+// NavMenu makes <nav> with a list of links. The link to currentPath, such as r.URL.Path, has aria-current="page",
+// and links to its ancestors, either in the menu or by path, have aria-current="true".
+// Links with a scheme and host are only marked when currentPath is a full URL with the same scheme and host.
+func NavMenu(currentPath string, items ...NavItem) NavMenuView {
+	return NavMenuView{currentPath: currentPath, items: items}
+}
+
+// Label sets the aria-label of the <nav>, such as "Primary"
+func (menu NavMenuView) Label(label string) NavMenuView {
+	menu.label = label
+	return menu
+}
+
+// ActiveClass adds class names to the links to the current page and its ancestors
+func (menu NavMenuView) ActiveClass(classNames ...string) NavMenuView {
+	menu.activeClasses = append(menu.activeClasses[:len(menu.activeClasses):len(menu.activeClasses)], classNames...)
+	return menu
+}
+
+// InactiveClass adds class names to every other link
+func (menu NavMenuView) InactiveClass(classNames ...string) NavMenuView {
+	menu.inactiveClasses = append(menu.inactiveClasses[:len(menu.inactiveClasses):len(menu.inactiveClasses)], classNames...)
+	return menu
+}
+
+// Use the provided enhancers
+func (menu NavMenuView) Use(enhancers ...HTMLEnhancer) NavMenuView {
+	menu.elementCore = menu.elementCore.Use(enhancers...)
+	return menu
+}
+
+func (menu NavMenuView) Class(classNames ...string) NavMenuView {
+	menu.elementCore.classNames = append(menu.elementCore.classNames, classNames...)
+	return menu
+}
+
+// cleanMenuURL returns the scheme and host of an absolute URL, which are empty for a path on this site, and the path without a trailing slash
+func cleanMenuURL(rawURL string) (origin string, path string) {
+	path = rawURL
+	if parsed, err := url.Parse(rawURL); err == nil {
+		path = parsed.Path
+		if parsed.Scheme != "" || parsed.Host != "" {
+			origin = strings.ToLower(parsed.Scheme + "://" + parsed.Host)
+		}
+	}
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return origin, path
+}
+
+// itemPath returns the path of the item’s URL, and false if it links to another site
+func (menu NavMenuView) itemPath(item NavItem) (string, bool) {
+	itemOrigin, itemPath := cleanMenuURL(item.url)
+	currentOrigin, _ := cleanMenuURL(menu.currentPath)
+	return itemPath, itemOrigin == "" || itemOrigin == currentOrigin
+}
+
+func (menu NavMenuView) isCurrent(item NavItem) bool {
+	itemPath, sameSite := menu.itemPath(item)
+	_, currentPath := cleanMenuURL(menu.currentPath)
+	return sameSite && itemPath == currentPath
+}
+
+// isAncestor reports whether the item contains the current page, either in its submenu or below its path
+func (menu NavMenuView) isAncestor(item NavItem) bool {
+	for _, child := range item.children {
+		if menu.isCurrent(child) || menu.isAncestor(child) {
+			return true
+		}
+	}
+
+	itemPath, sameSite := menu.itemPath(item)
+	_, currentPath := cleanMenuURL(menu.currentPath)
+	return sameSite && itemPath != "/" && itemPath != "" && strings.HasPrefix(currentPath, itemPath+"/")
+}
+
+func (menu NavMenuView) listView(items []NavItem) HTMLView {
+	views := make([]HTMLView, 0, len(items))
+	for _, item := range items {
+		link := Link(item.url, Text(item.label))
+		switch {
+		case menu.isCurrent(item):
+			link = link.Use(AriaCurrentPage).AddClasses(menu.activeClasses)
+		case menu.isAncestor(item):
+			link = link.Use(AriaAttr("current", "true")).AddClasses(menu.activeClasses)
+		default:
+			link = link.AddClasses(menu.inactiveClasses)
+		}
+
+		if len(item.children) > 0 {
+			views = append(views, Li(link, menu.listView(item.children)))
+		} else {
+			views = append(views, Li(link))
+		}
+	}
+	return Ul(views...)
+}
+
+func (menu NavMenuView) apply(node *html.Node, ctx *buildContext) {
+	nav := Nav(menu.listView(menu.items)).UseWhen(menu.label != "", AriaLabel(menu.label))
+	nav.elementCore.children = append(nav.elementCore.children, menu.elementCore.children...)
+	nav.elementCore.classNames = menu.elementCore.classNames
+	nav.apply(node, ctx)
+}
@@ -0,0 +1,57 @@
+package dovetail
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestNavMenu(t *testing.T) {
+	items := []NavItem{
+		MenuItem("Home", "/"),
+		MenuItem("Docs", "/docs",
+			MenuItem("Forms", "/docs/forms"),
+			MenuItem("Tables", "/docs/tables"),
+		),
+		MenuItem("Blog", "/blog"),
+	}
+
+	t.Run("Rendering NavMenu for a page in a submenu", func(t *testing.T) {
+		s := subjectAsString(NavMenu("/docs/forms/", items...).Label("Primary").ActiveClass("font-bold").InactiveTailwind(TextSM))
+
+		t.Run(`it marks the current page and its ancestors`, func(t *testing.T) {
+			assert.Equal(t, s, `<nav aria-label="Primary"><ul>`+
+				`<li><a href="/" class="text-sm">Home</a></li>`+
+				`<li><a href="/docs" aria-current="true" class="font-bold">Docs</a><ul>`+
+				`<li><a href="/docs/forms" aria-current="page" class="font-bold">Forms</a></li>`+
+				`<li><a href="/docs/tables" class="text-sm">Tables</a></li>`+
+				`</ul></li>`+
+				`<li><a href="/blog" class="text-sm">Blog</a></li>`+
+				`</ul></nav>`)
+		})
+	})
+
+	t.Run("Rendering NavMenu for a page below an item’s path", func(t *testing.T) {
+		s := subjectAsString(NavMenu("/blog/hello-world?ref=home", MenuItem("Home", "/"), MenuItem("Blog", "/blog")))
+
+		t.Run(`it marks the item as an ancestor but not the home page`, func(t *testing.T) {
+			assert.Equal(t, s, `<nav><ul><li><a href="/">Home</a></li><li><a href="/blog" aria-current="true">Blog</a></li></ul></nav>`)
+		})
+	})
+
+	t.Run("Rendering NavMenu with links to other sites", func(t *testing.T) {
+		s := subjectAsString(NavMenu("/docs", MenuItem("Docs", "/docs"), MenuItem("GitHub", "https://github.com/docs"), MenuItem("Email", "mailto:docs")))
+
+		t.Run(`it does not mark them even when the paths match`, func(t *testing.T) {
+			assert.Equal(t, s, `<nav><ul><li><a href="/docs" aria-current="page">Docs</a></li><li><a href="https://github.com/docs">GitHub</a></li><li><a href="mailto:docs">Email</a></li></ul></nav>`)
+		})
+	})
+
+	t.Run("Rendering NavMenu for a full URL", func(t *testing.T) {
+		s := subjectAsString(NavMenu("https://example.org/docs/forms", MenuItem("Docs", "https://EXAMPLE.org/docs"), MenuItem("Forms", "/docs/forms"), MenuItem("Other", "https://example.com/docs/forms")))
+
+		t.Run(`it marks links with the same scheme and host`, func(t *testing.T) {
+			assert.Equal(t, s, `<nav><ul><li><a href="https://EXAMPLE.org/docs" aria-current="true">Docs</a></li><li><a href="/docs/forms" aria-current="page">Forms</a></li><li><a href="https://example.com/docs/forms">Other</a></li></ul></nav>`)
+		})
+	})
+}
@@ -0,0 +1,157 @@
+package dovetail
+
+import (
+	"strconv"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// PaginationView makes navigation between the pages of a list
+type PaginationView struct {
+	page        int
+	pageSize    int
+	total       int
+	pageURL     func(page int) string
+	window      int
+	label       string
+	elementCore HTMLElementCore
+}
+
+// Pagination makes <nav> with links to the previous and next pages and numbered pages around the current page.
+// Pages are numbered from 1, and pageURL returns the URL of each page, such as "?page=2".
+func Pagination(page int, pageSize int, total int, pageURL func(page int) string) PaginationView {
+	return PaginationView{page: page, pageSize: pageSize, total: total, pageURL: pageURL, window: 2, label: "Pagination"}
+}
+
+// Window changes how many pages are linked either side of the current page, which defaults to 2.
+// The first and last pages are always linked, with an ellipsis for the pages left out.
+func (pagination PaginationView) Window(pages int) PaginationView {
+	pagination.window = pages
+	return pagination
+}
+
+// Label changes the aria-label of the <nav>, which defaults to "Pagination"
+func (pagination PaginationView) Label(label string) PaginationView {
+	pagination.label = label
+	return pagination
+}
+
+// Use the provided enhancers
+func (pagination PaginationView) Use(enhancers ...HTMLEnhancer) PaginationView {
+	pagination.elementCore = pagination.elementCore.Use(enhancers...)
+	return pagination
+}
+
+func (pagination PaginationView) Class(classNames ...string) PaginationView {
+	pagination.elementCore.classNames = append(pagination.elementCore.classNames, classNames...)
+	return pagination
+}
+
+func (pagination PaginationView) pageCount() int {
+	if pagination.pageSize <= 0 || pagination.total <= 0 {
+		return 1
+	}
+	return (pagination.total-1)/pagination.pageSize + 1
+}
+
+func (pagination PaginationView) currentPage() int {
+	page := pagination.page
+	if count := pagination.pageCount(); page > count {
+		page = count
+	}
+	if page < 1 {
+		page = 1
+	}
+	return page
+}
+
+// windowedPages returns the page numbers to link, with 0 where there is an ellipsis.
+// Only the pages around the current one are visited, so any number of pages can be linked.
+func (pagination PaginationView) windowedPages() []int {
+	count := pagination.pageCount()
+	current := pagination.currentPage()
+	window := pagination.window
+	if window < 0 {
+		window = 0
+	}
+	if window > count {
+		window = count
+	}
+	start, end := current-window, current+window
+	if start < 1 {
+		start = 1
+	}
+	if end > count {
+		end = count
+	}
+
+	pages := make([]int, 0, end-start+5)
+	if start > 1 {
+		pages = append(pages, 1)
+		if start == 3 {
+			// An ellipsis would hide just one page, so link it instead
+			pages = append(pages, 2)
+		} else if start > 3 {
+			pages = append(pages, 0)
+		}
+	}
+	for page := start; page <= end; page++ {
+		pages = append(pages, page)
+	}
+	if end < count {
+		if end == count-2 {
+			pages = append(pages, count-1)
+		} else if end < count-2 {
+			pages = append(pages, 0)
+		}
+		pages = append(pages, count)
+	}
+	return pages
+}
+
+func (pagination PaginationView) apply(node *html.Node, ctx *buildContext) {
+	count := pagination.pageCount()
+	current := pagination.currentPage()
+	pages := pagination.windowedPages()
+
+	var headLinks []HTMLView
+	items := make([]HTMLView, 0, len(pages)+2)
+
+	if current > 1 {
+		prevURL := pagination.pageURL(current - 1)
+		items = append(items, Li(Link(prevURL, Text("Previous"), CustomAttr("rel", "prev"))))
+		headLinks = append(headLinks, HTMLElementViewOf("link", atom.Link, []HTMLView{CustomAttr("rel", "prev"), CustomAttr("href", prevURL)}))
+	} else {
+		// Plain text, as there is no previous page to link to
+		items = append(items, Li(Text("Previous")))
+	}
+
+	for _, page := range pages {
+		if page == 0 {
+			items = append(items, Li(AriaHidden(), Text("…")))
+			continue
+		}
+		link := Link(pagination.pageURL(page), Text(strconv.Itoa(page)))
+		if page == current {
+			link = link.Use(AriaCurrentPage)
+		}
+		items = append(items, Li(link))
+	}
+
+	if current < count {
+		nextURL := pagination.pageURL(current + 1)
+		items = append(items, Li(Link(nextURL, Text("Next"), CustomAttr("rel", "next"))))
+		headLinks = append(headLinks, HTMLElementViewOf("link", atom.Link, []HTMLView{CustomAttr("rel", "next"), CustomAttr("href", nextURL)}))
+	} else {
+		items = append(items, Li(Text("Next")))
+	}
+
+	nav := Nav(AriaLabel(pagination.label), Ul(items...))
+	nav.elementCore.children = append(nav.elementCore.children, pagination.elementCore.children...)
+	nav.elementCore.classNames = pagination.elementCore.classNames
+	if headLinks != nil {
+		nav = nav.Use(Head(headLinks...))
+	}
+	nav.apply(node, ctx)
+}
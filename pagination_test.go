@@ -0,0 +1,83 @@
+package dovetail
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestPagination(t *testing.T) {
+	pageURL := func(page int) string { return "?page=" + strconv.Itoa(page) }
+
+	t.Run("Rendering a middle page", func(t *testing.T) {
+		body := new(bytes.Buffer)
+		head := new(bytes.Buffer)
+		err := RenderWithHead(body, head, Pagination(6, 10, 95, pageURL).Window(1))
+
+		t.Run(`it renders previous and next links, windowed pages with ellipses and the current page`, func(t *testing.T) {
+			assert.NilError(t, err)
+			assert.Equal(t, body.String(), `<nav aria-label="Pagination"><ul>`+
+				`<li><a href="?page=5" rel="prev">Previous</a></li>`+
+				`<li><a href="?page=1">1</a></li>`+
+				`<li aria-hidden="true">…</li>`+
+				`<li><a href="?page=5">5</a></li>`+
+				`<li><a href="?page=6" aria-current="page">6</a></li>`+
+				`<li><a href="?page=7">7</a></li>`+
+				`<li aria-hidden="true">…</li>`+
+				`<li><a href="?page=10">10</a></li>`+
+				`<li><a href="?page=7" rel="next">Next</a></li>`+
+				`</ul></nav>`)
+		})
+
+		t.Run(`it adds prev and next links to the <head>`, func(t *testing.T) {
+			assert.Equal(t, head.String(), `<link rel="prev" href="?page=5"/><link rel="next" href="?page=7"/>`)
+		})
+	})
+
+	t.Run("Rendering the first page", func(t *testing.T) {
+		s := subjectAsString(Pagination(1, 10, 40, pageURL).Label("Results"))
+
+		t.Run(`it renders previous as text and links every page when no ellipsis is needed`, func(t *testing.T) {
+			assert.Equal(t, s, `<nav aria-label="Results"><ul>`+
+				`<li>Previous</li>`+
+				`<li><a href="?page=1" aria-current="page">1</a></li>`+
+				`<li><a href="?page=2">2</a></li>`+
+				`<li><a href="?page=3">3</a></li>`+
+				`<li><a href="?page=4">4</a></li>`+
+				`<li><a href="?page=2" rel="next">Next</a></li>`+
+				`</ul></nav>`)
+		})
+	})
+
+	t.Run("Rendering one of very many pages", func(t *testing.T) {
+		s := subjectAsString(Pagination(3, 1, math.MaxInt32, pageURL).Window(1))
+
+		t.Run(`it links the pages around the current one without visiting the rest`, func(t *testing.T) {
+			assert.Equal(t, s, `<nav aria-label="Pagination"><ul>`+
+				`<li><a href="?page=2" rel="prev">Previous</a></li>`+
+				`<li><a href="?page=1">1</a></li>`+
+				`<li><a href="?page=2">2</a></li>`+
+				`<li><a href="?page=3" aria-current="page">3</a></li>`+
+				`<li><a href="?page=4">4</a></li>`+
+				`<li aria-hidden="true">…</li>`+
+				`<li><a href="?page=2147483647">2147483647</a></li>`+
+				`<li><a href="?page=4" rel="next">Next</a></li>`+
+				`</ul></nav>`)
+		})
+	})
+
+	t.Run("Rendering a page past the end", func(t *testing.T) {
+		s := subjectAsString(Pagination(9, 10, 0, pageURL))
+
+		t.Run(`it renders a single current page`, func(t *testing.T) {
+			assert.Equal(t, s, `<nav aria-label="Pagination"><ul>`+
+				`<li>Previous</li>`+
+				`<li><a href="?page=1" aria-current="page">1</a></li>`+
+				`<li>Next</li>`+
+				`</ul></nav>`)
+		})
+	})
+}
@@ -0,0 +1,110 @@
+package dovetail
+
+import "strings"
+
+// Plural categories from the Unicode CLDR
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// baseLanguage returns the language of a locale such as "pt-BR" or "zh_Hant", in lowercase
+func baseLanguage(locale string) string {
+	locale = strings.ToLower(locale)
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		return locale[:i]
+	}
+	return locale
+}
+
+// PluralCategories returns the categories used by the locale for whole numbers, in CLDR order, such as "one" and "other" for English
+func PluralCategories(locale string) []string {
+	switch baseLanguage(locale) {
+	case "ja", "ko", "zh", "th", "vi", "id", "ms", "lo", "km", "my":
+		return []string{PluralOther}
+	case "ru", "uk", "be", "pl":
+		return []string{PluralOne, PluralFew, PluralMany, PluralOther}
+	case "cs", "sk":
+		return []string{PluralOne, PluralFew, PluralOther}
+	case "ar":
+		return []string{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther}
+	case "he":
+		return []string{PluralOne, PluralTwo, PluralOther}
+	}
+	return []string{PluralOne, PluralOther}
+}
+
+// PluralCategory returns the CLDR plural category of the whole number n in the locale, such as "one" or "few".
+// Languages without specific rules use "one" for 1 and "other" for everything else, like English.
+func PluralCategory(locale string, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	mod10, mod100 := n%10, n%100
+
+	switch baseLanguage(locale) {
+	case "ja", "ko", "zh", "th", "vi", "id", "ms", "lo", "km", "my":
+		return PluralOther
+	case "fr", "pt":
+		// Zero is singular, except in Portugal
+		if n == 1 || (n == 0 && !strings.EqualFold(locale, "pt-PT") && !strings.EqualFold(locale, "pt_PT")) {
+			return PluralOne
+		}
+		return PluralOther
+	case "ru", "uk", "be":
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return PluralOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFew
+		}
+		return PluralMany
+	case "pl":
+		switch {
+		case n == 1:
+			return PluralOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFew
+		}
+		return PluralMany
+	case "cs", "sk":
+		switch {
+		case n == 1:
+			return PluralOne
+		case n >= 2 && n <= 4:
+			return PluralFew
+		}
+		return PluralOther
+	case "ar":
+		switch {
+		case n == 0:
+			return PluralZero
+		case n == 1:
+			return PluralOne
+		case n == 2:
+			return PluralTwo
+		case mod100 >= 3 && mod100 <= 10:
+			return PluralFew
+		case mod100 >= 11:
+			return PluralMany
+		}
+		return PluralOther
+	case "he":
+		switch n {
+		case 1:
+			return PluralOne
+		case 2:
+			return PluralTwo
+		}
+		return PluralOther
+	}
+
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
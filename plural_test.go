@@ -0,0 +1,43 @@
+package dovetail
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestPluralCategory(t *testing.T) {
+	t.Run(`it uses one and other for English`, func(t *testing.T) {
+		assert.Equal(t, PluralCategory("en", 1), PluralOne)
+		assert.Equal(t, PluralCategory("en-AU", 0), PluralOther)
+		assert.Equal(t, PluralCategory("en", 2), PluralOther)
+	})
+
+	t.Run(`it treats zero as singular in French and Brazilian Portuguese`, func(t *testing.T) {
+		assert.Equal(t, PluralCategory("fr", 0), PluralOne)
+		assert.Equal(t, PluralCategory("pt-BR", 0), PluralOne)
+		assert.Equal(t, PluralCategory("pt-PT", 0), PluralOther)
+	})
+
+	t.Run(`it uses one, few and many for Russian`, func(t *testing.T) {
+		assert.Equal(t, PluralCategory("ru", 1), PluralOne)
+		assert.Equal(t, PluralCategory("ru", 21), PluralOne)
+		assert.Equal(t, PluralCategory("ru", 11), PluralMany)
+		assert.Equal(t, PluralCategory("ru", 3), PluralFew)
+		assert.Equal(t, PluralCategory("ru", 13), PluralMany)
+		assert.Equal(t, PluralCategory("ru", 25), PluralMany)
+	})
+
+	t.Run(`it uses all six categories for Arabic`, func(t *testing.T) {
+		got := []string{}
+		for _, n := range []int{0, 1, 2, 5, 11, 100} {
+			got = append(got, PluralCategory("ar", n))
+		}
+		assert.DeepEqual(t, got, []string{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther})
+	})
+
+	t.Run(`it only uses other for Japanese`, func(t *testing.T) {
+		assert.Equal(t, PluralCategory("ja", 1), PluralOther)
+		assert.DeepEqual(t, PluralCategories("ja"), []string{PluralOther})
+	})
+}
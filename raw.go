@@ -0,0 +1,56 @@
+package dovetail
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// HTMLRawView adds nodes parsed from an HTML fragment
+type HTMLRawView struct {
+	source string
+	policy *SanitizePolicy
+}
+
+// RawHTML parses trusted HTML, such as the output of a Markdown renderer, and adds its nodes as-is.
+// Never pass content from users to RawHTML, use SanitizedHTML instead.
+func RawHTML(source string) HTMLRawView {
+	return HTMLRawView{source: source}
+}
+
+// SanitizedHTML parses untrusted HTML, and adds only the elements, attributes and URL schemes allowed by policy
+func SanitizedHTML(source string, policy SanitizePolicy) HTMLRawView {
+	return HTMLRawView{source: source, policy: &policy}
+}
+
+// parse parses the fragment as if it were the content of contextNode
+func (raw HTMLRawView) parse(contextNode *html.Node) []*html.Node {
+	if contextNode == nil || contextNode.Type != html.ElementNode {
+		contextNode = &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	}
+
+	nodes, err := html.ParseFragment(strings.NewReader(raw.source), contextNode)
+	if err != nil {
+		return nil
+	}
+
+	if raw.policy != nil {
+		nodes = raw.policy.sanitize(nodes)
+	}
+	return nodes
+}
+
+// appendTo parses the fragment in the context of parent and appends its nodes
+func (raw HTMLRawView) appendTo(parent *html.Node) {
+	for _, child := range raw.parse(parent) {
+		parent.AppendChild(child)
+	}
+}
+
+// apply is used when the fragment has no parent element, such as when rendered directly.
+// The node becomes a document fragment, which renders just its children.
+func (raw HTMLRawView) apply(node *html.Node, ctx *buildContext) {
+	node.Type = html.DocumentNode
+	raw.appendTo(node)
+}
@@ -0,0 +1,76 @@
+package dovetail
+
+import (
+	"testing"
+
+	"golang.org/x/net/html/atom"
+	"gotest.tools/assert"
+)
+
+func TestRawHTML(t *testing.T) {
+	t.Run("Rendering RawHTML within an element", func(t *testing.T) {
+		s := subjectAsString(Article(H(1, Text("Notes")), RawHTML(`<p>Some <em>markdown</em></p><ul><li>One</li></ul>`)))
+
+		t.Run(`it adds the parsed nodes as children`, func(t *testing.T) {
+			assert.Equal(t, s, `<article><h1>Notes</h1><p>Some <em>markdown</em></p><ul><li>One</li></ul></article>`)
+		})
+	})
+
+	t.Run("Rendering RawHTML directly", func(t *testing.T) {
+		s := subjectAsString(RawHTML(`<b>Bold</b> & plain`))
+
+		t.Run(`it renders the fragment without a wrapper`, func(t *testing.T) {
+			assert.Equal(t, s, `<b>Bold</b> &amp; plain`)
+		})
+	})
+
+	t.Run("Rendering RawHTML table rows within a table", func(t *testing.T) {
+		s := subjectAsString(HTMLElementViewOf("table", atom.Table, []HTMLView{RawHTML(`<tr><td>Cell</td></tr>`)}))
+
+		t.Run(`it parses in the context of the parent element`, func(t *testing.T) {
+			assert.Equal(t, s, `<table><tbody><tr><td>Cell</td></tr></tbody></table>`)
+		})
+	})
+
+	t.Run("Rendering RawHTML within List", func(t *testing.T) {
+		s := subjectAsString(List(RawHTML(`<b>One</b> and <i>two</i>`)))
+
+		t.Run(`it wraps the whole fragment in one <li>`, func(t *testing.T) {
+			assert.Equal(t, s, `<ul><li><b>One</b> and <i>two</i></li></ul>`)
+		})
+	})
+}
+
+func TestSanitizedHTML(t *testing.T) {
+	t.Run("Rendering untrusted HTML with the UGC policy", func(t *testing.T) {
+		s := subjectAsString(Div(SanitizedHTML(
+			`<p onclick="steal()" class="x">Hi <a href="javascript:alert(1)">bad</a> <a href=" https://example.org/" title="ok">good</a></p>`+
+				`<script>alert(1)</script><custom-tag>unwrapped <strong>text</strong></custom-tag><!-- comment -->`+
+				`<img src="/cat.png" alt="Cat" onerror="steal()"><iframe src="https://evil.example"></iframe>`,
+			UGCPolicy(),
+		)))
+
+		t.Run(`it only keeps allowed elements, attributes and URLs`, func(t *testing.T) {
+			assert.Equal(t, s, `<div><p>Hi <a>bad</a> <a href=" https://example.org/" title="ok">good</a></p>unwrapped <strong>text</strong><img src="/cat.png" alt="Cat"/></div>`)
+		})
+	})
+
+	t.Run("Rendering with a custom policy", func(t *testing.T) {
+		policy := NewSanitizePolicy().AllowElements("a").AllowAttributes("href", "onclick").AllowURLSchemes("https")
+		s := subjectAsString(Div(SanitizedHTML(`<a href="HTTPS://example.org" onclick="x()">A</a><a href="http://example.org">B</a><p>C</p>`, policy)))
+
+		t.Run(`it allows only what was listed, never event handlers`, func(t *testing.T) {
+			assert.Equal(t, s, `<div><a href="HTTPS://example.org">A</a><a>B</a>C</div>`)
+		})
+	})
+
+	t.Run("Extending a policy", func(t *testing.T) {
+		base := NewSanitizePolicy().AllowElements("b")
+		extended := base.AllowElements("i")
+
+		t.Run(`it does not change the original`, func(t *testing.T) {
+			assert.Equal(t, subjectAsString(Div(SanitizedHTML(`<b>B</b><i>I</i>`, base))), `<div><b>B</b>I</div>`)
+			assert.Equal(t, subjectAsString(Div(SanitizedHTML(`<b>B</b><i>I</i>`, extended))), `<div><b>B</b><i>I</i></div>`)
+		})
+	})
+}
@@ -0,0 +1,222 @@
+package dovetail
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+type sanitizeAttr struct {
+	element string
+	key     string
+}
+
+// SanitizePolicy is an allowlist of the elements, attributes and URL schemes kept by SanitizedHTML.
+// Elements not allowed are replaced by their children, except for elements such as <script> which are removed entirely.
+// Event handler attributes such as onclick are never allowed.
+type SanitizePolicy struct {
+	elements   []string
+	attributes []sanitizeAttr
+	urlSchemes []string
+}
+
+// NewSanitizePolicy makes a policy that allows only text, to be extended with the Allow methods
+func NewSanitizePolicy() SanitizePolicy {
+	return SanitizePolicy{}
+}
+
+// UGCPolicy makes a policy suitable for user-generated content such as comments, allowing formatting, links and images
+func UGCPolicy() SanitizePolicy {
+	return NewSanitizePolicy().
+		AllowElements("p", "br", "hr", "h1", "h2", "h3", "h4", "h5", "h6").
+		AllowElements("strong", "b", "em", "i", "u", "s", "del", "ins", "mark", "small", "sub", "sup").
+		AllowElements("code", "pre", "kbd", "samp", "blockquote", "q", "abbr", "cite").
+		AllowElements("ul", "ol", "li", "dl", "dt", "dd").
+		AllowElements("table", "caption", "thead", "tbody", "tfoot", "tr", "th", "td").
+		AllowElements("a", "img", "figure", "figcaption").
+		AllowAttributesOn("a", "href", "title").
+		AllowAttributesOn("img", "src", "alt", "title", "width", "height").
+		AllowAttributesOn("abbr", "title").
+		AllowAttributesOn("blockquote", "cite").
+		AllowAttributesOn("q", "cite").
+		AllowAttributesOn("ol", "start", "reversed", "type").
+		AllowAttributesOn("th", "scope", "colspan", "rowspan").
+		AllowAttributesOn("td", "colspan", "rowspan").
+		AllowURLSchemes("http", "https", "mailto")
+}
+
+// AllowElements allows the elements with the tag names
+func (policy SanitizePolicy) AllowElements(tagNames ...string) SanitizePolicy {
+	policy.elements = append(policy.elements[:len(policy.elements):len(policy.elements)], tagNames...)
+	return policy
+}
+
+// AllowAttributes allows the attributes on any allowed element
+func (policy SanitizePolicy) AllowAttributes(keys ...string) SanitizePolicy {
+	return policy.AllowAttributesOn("", keys...)
+}
+
+// AllowAttributesOn allows the attributes on the element with the tag name
+func (policy SanitizePolicy) AllowAttributesOn(tagName string, keys ...string) SanitizePolicy {
+	attributes := policy.attributes[:len(policy.attributes):len(policy.attributes)]
+	for _, key := range keys {
+		attributes = append(attributes, sanitizeAttr{element: tagName, key: key})
+	}
+	policy.attributes = attributes
+	return policy
+}
+
+// AllowURLSchemes allows URLs with the schemes, such as "https" or "mailto", in attributes such as href and src.
+// Relative URLs are always allowed.
+func (policy SanitizePolicy) AllowURLSchemes(schemes ...string) SanitizePolicy {
+	policy.urlSchemes = append(policy.urlSchemes[:len(policy.urlSchemes):len(policy.urlSchemes)], schemes...)
+	return policy
+}
+
+func (policy SanitizePolicy) allowsElement(tagName string) bool {
+	for _, element := range policy.elements {
+		if element == tagName {
+			return true
+		}
+	}
+	return false
+}
+
+func (policy SanitizePolicy) allowsAttr(tagName string, attr html.Attribute) bool {
+	key := strings.ToLower(attr.Key)
+	if attr.Namespace != "" || strings.HasPrefix(key, "on") {
+		return false
+	}
+
+	allowed := false
+	for _, allowedAttr := range policy.attributes {
+		if allowedAttr.key == key && (allowedAttr.element == "" || allowedAttr.element == tagName) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return false
+	}
+
+	if isURLAttr(key) {
+		return hasAllowedScheme(attr.Val, policy.urlSchemes)
+	}
+	return true
+}
+
+// removedWithContentAtoms are elements whose content is dangerous or meaningless outside them
+var removedWithContentAtoms = map[atom.Atom]bool{
+	atom.Embed:    true,
+	atom.Frame:    true,
+	atom.Frameset: true,
+	atom.Iframe:   true,
+	atom.Math:     true,
+	atom.Noembed:  true,
+	atom.Noframes: true,
+	atom.Noscript: true,
+	atom.Object:   true,
+	atom.Script:   true,
+	atom.Select:   true,
+	atom.Style:    true,
+	atom.Svg:      true,
+	atom.Template: true,
+	atom.Textarea: true,
+	atom.Title:    true,
+	atom.Xmp:      true,
+}
+
+// sanitize returns the nodes filtered by the policy
+func (policy SanitizePolicy) sanitize(nodes []*html.Node) []*html.Node {
+	sanitized := make([]*html.Node, 0, len(nodes))
+	for _, node := range nodes {
+		sanitized = append(sanitized, policy.sanitizeNode(node)...)
+	}
+	return sanitized
+}
+
+func (policy SanitizePolicy) sanitizeNode(node *html.Node) []*html.Node {
+	switch node.Type {
+	case html.TextNode:
+		return []*html.Node{node}
+	case html.ElementNode:
+	default:
+		// Comments and doctypes are dropped
+		return nil
+	}
+
+	if node.Namespace != "" || removedWithContentAtoms[node.DataAtom] {
+		return nil
+	}
+
+	var children []*html.Node
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		node.RemoveChild(child)
+		children = append(children, policy.sanitizeNode(child)...)
+		child = next
+	}
+
+	if !policy.allowsElement(node.Data) {
+		return children
+	}
+
+	attrs := make([]html.Attribute, 0, len(node.Attr))
+	for _, attr := range node.Attr {
+		if policy.allowsAttr(node.Data, attr) {
+			attrs = append(attrs, attr)
+		}
+	}
+	node.Attr = attrs
+
+	for _, child := range children {
+		node.AppendChild(child)
+	}
+	return []*html.Node{node}
+}
+
+// isURLAttr reports whether the attribute’s value is loaded or navigated to as a URL
+func isURLAttr(key string) bool {
+	switch key {
+	case "action", "background", "cite", "data", "formaction", "href", "icon", "longdesc", "manifest", "ping", "poster", "src", "xlink:href":
+		return true
+	}
+	return false
+}
+
+// urlScheme returns the lowercase scheme of rawURL, or "" if it is relative
+func urlScheme(rawURL string) string {
+	// Browsers ignore leading whitespace and control characters, and tabs and newlines anywhere
+	cleaned := strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, rawURL)
+	cleaned = strings.TrimLeft(cleaned, "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x0b\x0c\x0e\x0f\x10\x11\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x1b\x1c\x1d\x1e\x1f ")
+
+	for i, r := range cleaned {
+		switch {
+		case r == ':':
+			return strings.ToLower(cleaned[:i])
+		case r == '/' || r == '?' || r == '#':
+			return ""
+		}
+	}
+	return ""
+}
+
+// hasAllowedScheme reports whether rawURL is relative or has one of the schemes
+func hasAllowedScheme(rawURL string, schemes []string) bool {
+	scheme := urlScheme(rawURL)
+	if scheme == "" {
+		return true
+	}
+	for _, allowed := range schemes {
+		if strings.EqualFold(allowed, scheme) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package dovetail
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// InlineView makes <script> or <style> with inline source, adding the request’s CSP nonce when rendered with RenderContext
+type InlineView struct {
+	tagName     string
+	tagAtom     atom.Atom
+	source      string
+	elementCore HTMLElementCore
+}
+
+// InlineScript makes <script> with the JavaScript source
+func InlineScript(source string, enhancers ...HTMLEnhancer) InlineView {
+	return InlineView{tagName: "script", tagAtom: atom.Script, source: source, elementCore: HTMLElementCore{}.Use(enhancers...)}
+}
+
+// InlineStyle makes <style> with the CSS source
+func InlineStyle(source string, enhancers ...HTMLEnhancer) InlineView {
+	return InlineView{tagName: "style", tagAtom: atom.Style, source: source, elementCore: HTMLElementCore{}.Use(enhancers...)}
+}
+
+func (view InlineView) apply(node *html.Node, ctx *buildContext) {
+	node.Type = html.ElementNode
+	node.Data = view.tagName
+	node.DataAtom = view.tagAtom
+
+	if nonce := CSPNonce(ctx.context()); nonce != "" {
+		node.Attr = append(node.Attr, html.Attribute{Key: "nonce", Val: nonce})
+	}
+	view.elementCore.applyToNode(node, ctx)
+
+	// The source is rendered as-is, so it must not close its own element
+	if strings.Contains(strings.ToLower(view.source), "</"+view.tagName) {
+		ctx.report(fmt.Errorf("dovetail: inline <%s> must not contain </%s", view.tagName, view.tagName))
+		return
+	}
+	node.AppendChild(&html.Node{Type: html.TextNode, Data: view.source})
+}
@@ -0,0 +1,254 @@
+package dovetail
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// SVGElement makes an element in the SVG namespace, such as "circle" or "g"
+func SVGElement(tagName string, children ...HTMLView) HTMLElementView {
+	view := HTMLElementViewOf(tagName, atom.Lookup([]byte(tagName)), children)
+	view.namespace = "svg"
+	return view
+}
+
+// SVG makes inline <svg> with the viewBox, such as "0 0 24 24"
+func SVG(viewBox string, children ...HTMLView) HTMLElementView {
+	return SVGElement("svg", append([]HTMLView{CustomAttr("viewBox", viewBox)}, children...)...)
+}
+
+// SVGPath makes <path> with the path data d
+func SVGPath(d string, enhancers ...HTMLEnhancer) HTMLElementView {
+	return SVGElement("path", CustomAttr("d", d)).Use(enhancers...)
+}
+
+// SVGUse makes <use> referencing another element, such as "#icon-close"
+func SVGUse(href string, enhancers ...HTMLEnhancer) HTMLElementView {
+	return SVGElement("use", CustomAttr("href", href)).Use(enhancers...)
+}
+
+// SVGTitle makes <title> within SVG, which names it for assistive technology
+func SVGTitle(text string) HTMLElementView {
+	return SVGElement("title", Text(text))
+}
+
+// decorativeSVG hides the SVG from assistive technology, and stops old browsers focusing it
+var decorativeSVG = combinedView{views: []HTMLView{AriaHidden(), CustomAttr("focusable", "false")}}
+
+type icon struct {
+	viewBox  string
+	children []*html.Node
+}
+
+// IconSet is a set of icons loaded from SVG files
+type IconSet struct {
+	icons map[string]icon
+}
+
+// LoadIcons loads every .svg file in fs, such as http.Dir("icons"). Each icon is named by its path without the extension, such as "arrows/left".
+// Only shapes, groups, gradients, masks, and clip paths are kept from each file, without event handler attributes, styles, or references outside the icon.
+func LoadIcons(fs http.FileSystem) (*IconSet, error) {
+	icons := &IconSet{icons: make(map[string]icon)}
+	if err := icons.load(fs, "/"); err != nil {
+		return nil, err
+	}
+	return icons, nil
+}
+
+func (icons *IconSet) load(fs http.FileSystem, dir string) error {
+	f, err := fs.Open(dir)
+	if err != nil {
+		return err
+	}
+	infos, err := f.Readdir(-1)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		filePath := path.Join(dir, info.Name())
+		if info.IsDir() {
+			if err := icons.load(fs, filePath); err != nil {
+				return err
+			}
+			continue
+		}
+		if path.Ext(filePath) != ".svg" {
+			continue
+		}
+
+		f, err := fs.Open(filePath)
+		if err != nil {
+			return err
+		}
+		loaded, err := parseIcon(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("dovetail: icon %s: %v", filePath, err)
+		}
+		icons.icons[strings.TrimPrefix(strings.TrimSuffix(filePath, ".svg"), "/")] = loaded
+	}
+	return nil
+}
+
+func parseIcon(r io.Reader) (icon, error) {
+	nodes, err := html.ParseFragment(r, &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return icon{}, err
+	}
+
+	for _, node := range nodes {
+		if node.Type != html.ElementNode || node.Namespace != "svg" || node.Data != "svg" {
+			continue
+		}
+
+		loaded := icon{}
+		loaded.viewBox, _ = attrValue(node, "viewBox")
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if clean := iconNode(child); clean != nil {
+				loaded.children = append(loaded.children, clean)
+			}
+		}
+		return loaded, nil
+	}
+	return icon{}, fmt.Errorf("no <svg> element")
+}
+
+// iconElements are the SVG elements kept from icon files. Scripts, <foreignObject>, <style>, animation, and anything else are dropped.
+var iconElements = map[string]bool{
+	"g": true, "path": true, "circle": true, "ellipse": true, "line": true, "polyline": true, "polygon": true, "rect": true,
+	"defs": true, "use": true, "clipPath": true, "mask": true, "linearGradient": true, "radialGradient": true, "stop": true,
+}
+
+// iconNode copies node if it is an allowed shape, keeping only attributes that are not event handlers and only references within the icon
+func iconNode(node *html.Node) *html.Node {
+	if node.Type != html.ElementNode || node.Namespace != "svg" || !iconElements[node.Data] {
+		return nil
+	}
+
+	clean := &html.Node{Type: html.ElementNode, DataAtom: node.DataAtom, Data: node.Data, Namespace: node.Namespace}
+	for _, attr := range node.Attr {
+		if iconAttrAllowed(attr) {
+			clean.Attr = append(clean.Attr, attr)
+		}
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if cleanChild := iconNode(child); cleanChild != nil {
+			clean.AppendChild(cleanChild)
+		}
+	}
+	return clean
+}
+
+func iconAttrAllowed(attr html.Attribute) bool {
+	if attr.Key == "href" && (attr.Namespace == "" || attr.Namespace == "xlink") {
+		return strings.HasPrefix(attr.Val, "#")
+	}
+	if attr.Namespace != "" || attr.Key == "style" || validateAttrKey(attr.Key, false) != nil {
+		return false
+	}
+	// Paint and clip references such as fill="url(#gradient)" must stay within the icon
+	parts := strings.Split(strings.ToLower(attr.Val), "url(")
+	for _, part := range parts[1:] {
+		if !strings.HasPrefix(strings.TrimLeft(part, " \t\n\"'"), "#") {
+			return false
+		}
+	}
+	return true
+}
+
+// cloneNode deeply copies node, so nodes loaded once can be added to many trees
+func cloneNode(node *html.Node) *html.Node {
+	clone := &html.Node{
+		Type:      node.Type,
+		DataAtom:  node.DataAtom,
+		Data:      node.Data,
+		Namespace: node.Namespace,
+		Attr:      append([]html.Attribute(nil), node.Attr...),
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		clone.AppendChild(cloneNode(child))
+	}
+	return clone
+}
+
+// IconView renders an icon from an IconSet as inline <svg>
+type IconView struct {
+	icons       *IconSet
+	name        string
+	title       string
+	sprite      bool
+	elementCore HTMLElementCore
+}
+
+// Icon renders the icon with all its shapes inline
+func (icons *IconSet) Icon(name string) IconView {
+	return IconView{icons: icons, name: name}
+}
+
+// Sprite renders the icon with <use>. The icon’s shapes are rendered once per page as a <symbol> where it is first used.
+func (icons *IconSet) Sprite(name string) IconView {
+	return IconView{icons: icons, name: name, sprite: true}
+}
+
+// Title makes the icon meaningful, naming it with <title> and role="img". Icons without a title are hidden from assistive technology.
+func (view IconView) Title(title string) IconView {
+	view.title = title
+	return view
+}
+
+// Use the provided enhancers
+func (view IconView) Use(enhancers ...HTMLEnhancer) IconView {
+	view.elementCore = view.elementCore.Use(enhancers...)
+	return view
+}
+
+func (view IconView) Class(classNames ...string) IconView {
+	view.elementCore.classNames = append(view.elementCore.classNames, classNames...)
+	return view
+}
+
+func (view IconView) apply(node *html.Node, ctx *buildContext) {
+	loaded, ok := view.icons.icons[view.name]
+	if !ok {
+		ctx.report(fmt.Errorf("dovetail: icon %q was not loaded", view.name))
+	}
+
+	svg := SVG(loaded.viewBox)
+	if view.title == "" {
+		svg = svg.Use(decorativeSVG)
+	} else {
+		svg = svg.Use(CustomAttr("role", "img"))
+	}
+	svg.elementCore.children = append(svg.elementCore.children, view.elementCore.children...)
+	svg.elementCore.classNames = view.elementCore.classNames
+	svg.apply(node, ctx)
+
+	if view.title != "" {
+		node.AppendChild(ctx.build(SVGTitle(view.title)))
+	}
+
+	if !view.sprite {
+		for _, child := range loaded.children {
+			node.AppendChild(cloneNode(child))
+		}
+		return
+	}
+
+	id := "icon-" + strings.Replace(view.name, "/", "-", -1)
+	if ok && ctx.firstTime("svg symbol "+id) {
+		symbol := ctx.build(SVGElement("symbol", CustomAttr("id", id), CustomAttr("viewBox", loaded.viewBox)))
+		for _, child := range loaded.children {
+			symbol.AppendChild(cloneNode(child))
+		}
+		node.AppendChild(symbol)
+	}
+	node.AppendChild(ctx.build(SVGUse("#" + id)))
+}
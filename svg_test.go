@@ -0,0 +1,90 @@
+package dovetail
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestSVG(t *testing.T) {
+	t.Run("Rendering SVG elements", func(t *testing.T) {
+		node := Build(SVG("0 0 24 24", SVGPath("M6 18L18 6", CustomAttr("stroke", "currentColor")), SVGElement("circle", CustomAttr("r", "4"))))
+
+		t.Run(`it makes nodes in the SVG namespace`, func(t *testing.T) {
+			assert.Equal(t, node.Namespace, "svg")
+			assert.Equal(t, node.FirstChild.Namespace, "svg")
+		})
+
+		t.Run(`it renders <svg> with its children`, func(t *testing.T) {
+			assert.Equal(t, subjectAsString(SVG("0 0 24 24", SVGPath("M6 18L18 6"), SVGUse("#dot"))), `<svg viewBox="0 0 24 24"><path d="M6 18L18 6"></path><use href="#dot"></use></svg>`)
+		})
+	})
+}
+
+func TestIconSet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dovetail-icons")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "arrows"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "close.svg"), []byte(`<?xml version="1.0"?>
+<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><title>Close</title><path d="M6 18L18 6M6 6l12 12"/></svg>`), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "arrows", "left.svg"), []byte(`<svg viewBox="0 0 20 20"><path d="M10 4l-6 6 6 6"/></svg>`), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "unsafe.svg"), []byte(`<svg viewBox="0 0 16 16">`+
+		`<script>alert(1)</script><style>path { fill: url(https://example.org/) }</style>`+
+		`<foreignObject><div onclick="alert(1)">Hi</div></foreignObject>`+
+		`<g onclick="alert(1)" fill="url(#paint)" style="color: red"><path d="M0 0h16" stroke="url(https://example.org/)"/><use href="https://example.org/icon.svg#a"/><use xlink:href="#a"/></g>`+
+		`</svg>`), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte(`Not an icon`), 0644))
+
+	icons, err := LoadIcons(http.Dir(dir))
+	assert.NilError(t, err)
+
+	t.Run("Rendering a decorative icon", func(t *testing.T) {
+		s := subjectAsString(Button(icons.Icon("close").Class("w-4"), Text("Close")))
+
+		t.Run(`it renders the SVG inline, hidden from assistive technology`, func(t *testing.T) {
+			assert.Equal(t, s, `<button type="button"><svg viewBox="0 0 24 24" aria-hidden="true" focusable="false" class="w-4"><path d="M6 18L18 6M6 6l12 12"></path></svg>Close</button>`)
+		})
+	})
+
+	t.Run("Rendering a meaningful icon", func(t *testing.T) {
+		s := subjectAsString(icons.Icon("arrows/left").Title("Back"))
+
+		t.Run(`it renders a <title> and role img`, func(t *testing.T) {
+			assert.Equal(t, s, `<svg viewBox="0 0 20 20" role="img"><title>Back</title><path d="M10 4l-6 6 6 6"></path></svg>`)
+		})
+	})
+
+	t.Run("Rendering sprites", func(t *testing.T) {
+		s := subjectAsString(Div(icons.Sprite("arrows/left"), icons.Sprite("arrows/left")))
+
+		t.Run(`it renders the <symbol> once where first used`, func(t *testing.T) {
+			assert.Equal(t, s, `<div>`+
+				`<svg viewBox="0 0 20 20" aria-hidden="true" focusable="false"><symbol id="icon-arrows-left" viewBox="0 0 20 20"><path d="M10 4l-6 6 6 6"></path></symbol><use href="#icon-arrows-left"></use></svg>`+
+				`<svg viewBox="0 0 20 20" aria-hidden="true" focusable="false"><use href="#icon-arrows-left"></use></svg>`+
+				`</div>`)
+		})
+	})
+
+	t.Run("Rendering an icon with scripts and event handlers", func(t *testing.T) {
+		s := subjectAsString(icons.Icon("unsafe"))
+
+		t.Run(`it keeps only shapes and their safe attributes`, func(t *testing.T) {
+			assert.Equal(t, s, `<svg viewBox="0 0 16 16" aria-hidden="true" focusable="false"><g fill="url(#paint)"><path d="M0 0h16"></path><use></use><use xlink:href="#a"></use></g></svg>`)
+		})
+	})
+
+	t.Run("Rendering an icon that was not loaded", func(t *testing.T) {
+		err := Render(new(bytes.Buffer), icons.Icon("missing"))
+
+		t.Run(`it returns an error`, func(t *testing.T) {
+			assert.Error(t, err, `dovetail: icon "missing" was not loaded`)
+		})
+	})
+}
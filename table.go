@@ -0,0 +1,203 @@
+package dovetail
+
+import (
+	"net/url"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// TableColumn defines a column’s header and how to render its cell for each row
+type TableColumn struct {
+	header    HTMLView
+	cell      func(row int) HTMLView
+	align     string
+	sortKey   string
+	rowHeader bool
+}
+
+// Column makes a column with a header, rendering each cell by calling cell with the row’s index
+func Column(header HTMLView, cell func(row int) HTMLView) TableColumn {
+	return TableColumn{header: header, cell: cell}
+}
+
+// AlignRight aligns the column to the right, such as for numbers
+func (col TableColumn) AlignRight() TableColumn {
+	col.align = "right"
+	return col
+}
+
+// AlignCenter aligns the column to the center
+func (col TableColumn) AlignCenter() TableColumn {
+	col.align = "center"
+	return col
+}
+
+// Sortable lets the table be sorted by this column, with key passed to the sort link
+func (col TableColumn) Sortable(key string) TableColumn {
+	col.sortKey = key
+	return col
+}
+
+// RowHeader renders this column’s cells as <th scope="row">, so they label their row
+func (col TableColumn) RowHeader() TableColumn {
+	col.rowHeader = true
+	return col
+}
+
+// alignViews adds a class such as text-right, as a style attribute would be blocked by a Content-Security-Policy
+func (col TableColumn) alignViews() []HTMLView {
+	if col.align == "" {
+		return nil
+	}
+	return []HTMLView{Class("text-" + col.align)}
+}
+
+// TableView makes <table> from columns and a number of rows
+type TableView struct {
+	rowCount    int
+	columns     []TableColumn
+	caption     []HTMLView
+	footer      []HTMLView
+	sortKey     string
+	descending  bool
+	query       url.Values
+	sortURL     func(key string, descending bool) string
+	elementCore HTMLElementCore
+}
+
+// Table makes <table> with a <thead> of the columns’ headers, and a <tbody> with rowCount rows
+func Table(rowCount int, columns ...TableColumn) TableView {
+	return TableView{rowCount: rowCount, columns: columns}
+}
+
+// Caption adds <caption>, which names the table
+func (table TableView) Caption(children ...HTMLView) TableView {
+	table.caption = children
+	return table
+}
+
+// Footer adds <tfoot> with a row of cells, such as totals, aligned like their columns
+func (table TableView) Footer(cells ...HTMLView) TableView {
+	table.footer = cells
+	return table
+}
+
+// SortedBy marks the column with the sort key as sorted, adding aria-sort to its header
+func (table TableView) SortedBy(key string, descending bool) TableView {
+	table.sortKey = key
+	table.descending = descending
+	return table
+}
+
+// Query keeps the request’s other query values, such as filters, in the default sort links. The "page" value is dropped, so re-sorting starts from the first page.
+func (table TableView) Query(query url.Values) TableView {
+	table.query = query
+	return table
+}
+
+// SortLinks changes the URLs of the sortable headers’ links, which default to ?sort=key and ?sort=key&order=desc
+func (table TableView) SortLinks(sortURL func(key string, descending bool) string) TableView {
+	table.sortURL = sortURL
+	return table
+}
+
+// Use the provided enhancers
+func (table TableView) Use(enhancers ...HTMLEnhancer) TableView {
+	table.elementCore = table.elementCore.Use(enhancers...)
+	return table
+}
+
+func (table TableView) Class(classNames ...string) TableView {
+	table.elementCore.classNames = append(table.elementCore.classNames, classNames...)
+	return table
+}
+
+func (table TableView) defaultSortURL(key string, descending bool) string {
+	query := make(url.Values, len(table.query)+2)
+	for name, values := range table.query {
+		query[name] = values
+	}
+	query.Set("sort", key)
+	if descending {
+		query.Set("order", "desc")
+	} else {
+		query.Del("order")
+	}
+	// Re-sorting starts again from the first page
+	query.Del("page")
+	return "?" + query.Encode()
+}
+
+func (table TableView) headerView(col TableColumn) HTMLView {
+	children := append([]HTMLView{CustomAttr("scope", "col")}, col.alignViews()...)
+	if col.sortKey == "" {
+		return HTMLElementViewOf("th", atom.Th, append(children, col.header))
+	}
+
+	sortURL := table.sortURL
+	if sortURL == nil {
+		sortURL = table.defaultSortURL
+	}
+
+	// Following the link sorts by this column, reversing the order if already sorted by it
+	active := col.sortKey == table.sortKey
+	if active {
+		if table.descending {
+			children = append(children, AriaAttr("sort", "descending"))
+		} else {
+			children = append(children, AriaAttr("sort", "ascending"))
+		}
+	}
+	link := Link(sortURL(col.sortKey, active && !table.descending), col.header)
+	return HTMLElementViewOf("th", atom.Th, append(children, link))
+}
+
+func (table TableView) rowView(row int) HTMLView {
+	cells := make([]HTMLView, 0, len(table.columns))
+	for _, col := range table.columns {
+		children := append(col.alignViews(), col.cell(row))
+		if col.rowHeader {
+			cells = append(cells, HTMLElementViewOf("th", atom.Th, append([]HTMLView{CustomAttr("scope", "row")}, children...)))
+		} else {
+			cells = append(cells, HTMLElementViewOf("td", atom.Td, children))
+		}
+	}
+	return HTMLElementViewOf("tr", atom.Tr, cells)
+}
+
+func (table TableView) apply(node *html.Node, ctx *buildContext) {
+	node.Type = html.ElementNode
+	node.Data = "table"
+	node.DataAtom = atom.Table
+
+	table.elementCore.applyToNode(node, ctx)
+
+	if table.caption != nil {
+		node.AppendChild(ctx.build(HTMLElementViewOf("caption", atom.Caption, table.caption)))
+	}
+
+	headers := make([]HTMLView, 0, len(table.columns))
+	for _, col := range table.columns {
+		headers = append(headers, table.headerView(col))
+	}
+	node.AppendChild(ctx.build(HTMLElementViewOf("thead", atom.Thead, []HTMLView{HTMLElementViewOf("tr", atom.Tr, headers)})))
+
+	rows := make([]HTMLView, 0, table.rowCount)
+	for row := 0; row < table.rowCount; row++ {
+		rows = append(rows, table.rowView(row))
+	}
+	node.AppendChild(ctx.build(HTMLElementViewOf("tbody", atom.Tbody, rows)))
+
+	if table.footer != nil {
+		cells := make([]HTMLView, 0, len(table.footer))
+		for i, cell := range table.footer {
+			var children []HTMLView
+			if i < len(table.columns) {
+				children = table.columns[i].alignViews()
+			}
+			cells = append(cells, HTMLElementViewOf("td", atom.Td, append(children, cell)))
+		}
+		node.AppendChild(ctx.build(HTMLElementViewOf("tfoot", atom.Tfoot, []HTMLView{HTMLElementViewOf("tr", atom.Tr, cells)})))
+	}
+}
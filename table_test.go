@@ -0,0 +1,63 @@
+package dovetail
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestTable(t *testing.T) {
+	type fruit struct {
+		name  string
+		count int
+	}
+	fruits := []fruit{{"Apples", 3}, {"Pears", 5}}
+
+	name := Column(Text("Name"), func(row int) HTMLView { return Text(fruits[row].name) })
+	count := Column(Text("Count"), func(row int) HTMLView { return Text(strconv.Itoa(fruits[row].count)) }).AlignRight()
+
+	t.Run("Rendering a table with a caption and footer", func(t *testing.T) {
+		s := subjectAsString(Table(len(fruits), name.RowHeader(), count).Caption(Text("Fruit")).Footer(Text("Total"), Text("8")).Class("w-full"))
+
+		t.Run(`it renders <caption>, <thead>, <tbody> and <tfoot>`, func(t *testing.T) {
+			assert.Equal(t, s, `<table class="w-full"><caption>Fruit</caption>`+
+				`<thead><tr><th scope="col">Name</th><th scope="col" class="text-right">Count</th></tr></thead>`+
+				`<tbody><tr><th scope="row">Apples</th><td class="text-right">3</td></tr><tr><th scope="row">Pears</th><td class="text-right">5</td></tr></tbody>`+
+				`<tfoot><tr><td>Total</td><td class="text-right">8</td></tr></tfoot></table>`)
+		})
+	})
+
+	t.Run("Rendering sortable columns", func(t *testing.T) {
+		s := subjectAsString(Table(0, name.Sortable("name"), count.Sortable("count")).SortedBy("name", false))
+
+		t.Run(`it adds aria-sort to the sorted column and sort links to each`, func(t *testing.T) {
+			assert.Equal(t, s, `<table><thead><tr>`+
+				`<th scope="col" aria-sort="ascending"><a href="?order=desc&amp;sort=name">Name</a></th>`+
+				`<th scope="col" class="text-right"><a href="?sort=count">Count</a></th>`+
+				`</tr></thead><tbody></tbody></table>`)
+		})
+	})
+
+	t.Run("Rendering sort links with the request’s query", func(t *testing.T) {
+		s := subjectAsString(Table(0, name.Sortable("name")).SortedBy("name", true).Query(url.Values{"q": {"pear"}, "order": {"desc"}, "sort": {"name"}, "page": {"3"}}))
+
+		t.Run(`it keeps the other query values, going back to the first page`, func(t *testing.T) {
+			assert.Equal(t, s, `<table><thead><tr><th scope="col" aria-sort="descending"><a href="?q=pear&amp;sort=name">Name</a></th></tr></thead><tbody></tbody></table>`)
+		})
+	})
+
+	t.Run("Rendering with custom sort links", func(t *testing.T) {
+		s := subjectAsString(Table(0, name.Sortable("name")).SortedBy("name", true).SortLinks(func(key string, descending bool) string {
+			if descending {
+				return "/fruits/by-" + key + "/desc"
+			}
+			return "/fruits/by-" + key
+		}))
+
+		t.Run(`it uses the URLs`, func(t *testing.T) {
+			assert.Equal(t, s, `<table><thead><tr><th scope="col" aria-sort="descending"><a href="/fruits/by-name">Name</a></th></tr></thead><tbody></tbody></table>`)
+		})
+	})
+}
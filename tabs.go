@@ -0,0 +1,164 @@
+package dovetail
+
+import (
+	"net/url"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// TabPanel is a tab and its panel within Tabs
+type TabPanel struct {
+	key      string
+	label    HTMLView
+	children []HTMLView
+}
+
+// Tab makes a tab for Tabs, where key identifies it in links such as ?tab=key
+func Tab(key string, label HTMLView, children ...HTMLView) TabPanel {
+	return TabPanel{key: key, label: label, children: children}
+}
+
+// TabsView makes tabs that each show their panel
+type TabsView struct {
+	id          string
+	tabs        []TabPanel
+	selected    string
+	label       string
+	tabURL      func(key string) string
+	elementCore HTMLElementCore
+}
+
+// Tabs shows the panel of the selected tab. Without JavaScript the tabs are links that select the tab on the server.
+// In the WebAssembly build they follow the ARIA tabs pattern, with buttons that can be moved between with the arrow keys.
+// The id is used to make ids for each tab and panel.
+func Tabs(id string, tabs ...TabPanel) TabsView {
+	return TabsView{id: id, tabs: tabs}
+}
+
+// Selected selects the tab with the key, such as from the query, otherwise the first tab is selected
+func (tabs TabsView) Selected(key string) TabsView {
+	tabs.selected = key
+	return tabs
+}
+
+// Label sets the aria-label of the list of tabs
+func (tabs TabsView) Label(label string) TabsView {
+	tabs.label = label
+	return tabs
+}
+
+// Links changes the URLs that select each tab without JavaScript, which default to ?tab=key
+func (tabs TabsView) Links(tabURL func(key string) string) TabsView {
+	tabs.tabURL = tabURL
+	return tabs
+}
+
+// Use the provided enhancers
+func (tabs TabsView) Use(enhancers ...HTMLEnhancer) TabsView {
+	tabs.elementCore = tabs.elementCore.Use(enhancers...)
+	return tabs
+}
+
+func (tabs TabsView) Class(classNames ...string) TabsView {
+	tabs.elementCore.classNames = append(tabs.elementCore.classNames, classNames...)
+	return tabs
+}
+
+func defaultTabURL(key string) string {
+	return "?" + url.Values{"tab": {key}}.Encode()
+}
+
+func (tabs TabsView) selectedKey() string {
+	for _, tab := range tabs.tabs {
+		if tab.key == tabs.selected {
+			return tab.key
+		}
+	}
+	if len(tabs.tabs) > 0 {
+		return tabs.tabs[0].key
+	}
+	return ""
+}
+
+func (tabs TabsView) tabID(tab TabPanel) string {
+	return tabs.id + "-tab-" + tab.key
+}
+
+func (tabs TabsView) panelID(tab TabPanel) string {
+	return tabs.id + "-panel-" + tab.key
+}
+
+// linkViews renders a list of links and only the selected panel, which works without JavaScript
+func (tabs TabsView) linkViews() []HTMLView {
+	tabURL := tabs.tabURL
+	if tabURL == nil {
+		tabURL = defaultTabURL
+	}
+	selected := tabs.selectedKey()
+
+	links := make([]HTMLView, 0, len(tabs.tabs))
+	var panel HTMLView
+	for _, tab := range tabs.tabs {
+		link := Link(tabURL(tab.key), tab.label).Use(CustomAttr("id", tabs.tabID(tab)))
+		if tab.key == selected {
+			link = link.Use(AriaAttr("current", "true"))
+			panel = Div(tab.children...).Use(CustomAttr("id", tabs.panelID(tab)), CustomAttr("role", "region"), AriaAttr("labelledby", tabs.tabID(tab)))
+		}
+		links = append(links, link)
+	}
+
+	list := List(links...).UseWhen(tabs.label != "", AriaLabel(tabs.label))
+	return []HTMLView{list, panel}
+}
+
+// enhancedViews renders a tablist of buttons and every panel, following the ARIA tabs pattern
+func (tabs TabsView) enhancedViews() []HTMLView {
+	selected := tabs.selectedKey()
+
+	buttons := make([]HTMLView, 0, len(tabs.tabs))
+	views := make([]HTMLView, 0, len(tabs.tabs)+1)
+	for _, tab := range tabs.tabs {
+		isSelected := tab.key == selected
+
+		// Only the selected tab is in the tab order, the arrow keys move between tabs
+		button := Button(tab.label).Use(
+			CustomAttr("role", "tab"),
+			CustomAttr("id", tabs.tabID(tab)),
+			AriaAttr("controls", tabs.panelID(tab)),
+		)
+		if isSelected {
+			button = button.Use(AriaAttr("selected", "true"), FocusViaTab)
+		} else {
+			button = button.Use(AriaAttr("selected", "false"), FocusViaScript)
+		}
+		buttons = append(buttons, button)
+
+		panel := Div(tab.children...).
+			Use(CustomAttr("role", "tabpanel"), CustomAttr("id", tabs.panelID(tab)), AriaAttr("labelledby", tabs.tabID(tab)), FocusViaTab).
+			UseWhen(!isSelected, CustomAttr("hidden", ""))
+		views = append(views, panel)
+	}
+
+	tablist := Div(buttons...).Use(CustomAttr("role", "tablist")).UseWhen(tabs.label != "", AriaLabel(tabs.label))
+	return append([]HTMLView{tablist}, views...)
+}
+
+func (tabs TabsView) apply(node *html.Node, ctx *buildContext) {
+	node.Type = html.ElementNode
+	node.Data = "div"
+	node.DataAtom = atom.Div
+	node.Attr = []html.Attribute{{Key: "data-tabs", Val: tabs.id}}
+
+	tabs.elementCore.applyToNode(node, ctx)
+
+	views := tabs.linkViews()
+	if scriptEnhanced {
+		views = tabs.enhancedViews()
+	}
+	for _, view := range views {
+		if view != nil {
+			node.AppendChild(ctx.build(view))
+		}
+	}
+}
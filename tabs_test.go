@@ -0,0 +1,53 @@
+package dovetail
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestTabs(t *testing.T) {
+	tabs := Tabs("settings",
+		Tab("profile", Text("Profile"), P(Text("Your name"))),
+		Tab("billing", Text("Billing"), P(Text("Your card"))),
+	).Label("Settings")
+
+	t.Run("Rendering Tabs", func(t *testing.T) {
+		s := subjectAsString(tabs.Selected("billing"))
+
+		t.Run(`it renders links to select each tab and only the selected panel`, func(t *testing.T) {
+			assert.Equal(t, s, `<div data-tabs="settings">`+
+				`<ul aria-label="Settings"><li><a href="?tab=profile" id="settings-tab-profile">Profile</a></li><li><a href="?tab=billing" id="settings-tab-billing" aria-current="true">Billing</a></li></ul>`+
+				`<div id="settings-panel-billing" role="region" aria-labelledby="settings-tab-billing"><p>Your card</p></div>`+
+				`</div>`)
+		})
+	})
+
+	t.Run("Rendering Tabs with an unknown selection and custom links", func(t *testing.T) {
+		s := subjectAsString(Tabs("settings", Tab("profile", Text("Profile"))).Selected("missing").Links(func(key string) string {
+			return "/settings/" + key
+		}))
+
+		t.Run(`it selects the first tab`, func(t *testing.T) {
+			assert.Equal(t, s, `<div data-tabs="settings">`+
+				`<ul><li><a href="/settings/profile" id="settings-tab-profile" aria-current="true">Profile</a></li></ul>`+
+				`<div id="settings-panel-profile" role="region" aria-labelledby="settings-tab-profile"></div>`+
+				`</div>`)
+		})
+	})
+
+	t.Run("Rendering Tabs enhanced by WebAssembly", func(t *testing.T) {
+		s := renderScriptEnhanced(func() HTMLView { return tabs })
+
+		t.Run(`it renders a tablist with roving tabindex and every panel`, func(t *testing.T) {
+			assert.Equal(t, s, `<div data-tabs="settings">`+
+				`<div role="tablist" aria-label="Settings">`+
+				`<button type="button" role="tab" id="settings-tab-profile" aria-controls="settings-panel-profile" aria-selected="true" tabindex="0">Profile</button>`+
+				`<button type="button" role="tab" id="settings-tab-billing" aria-controls="settings-panel-billing" aria-selected="false" tabindex="-1">Billing</button>`+
+				`</div>`+
+				`<div role="tabpanel" id="settings-panel-profile" aria-labelledby="settings-tab-profile" tabindex="0"><p>Your name</p></div>`+
+				`<div role="tabpanel" id="settings-panel-billing" aria-labelledby="settings-tab-billing" tabindex="0" hidden=""><p>Your card</p></div>`+
+				`</div>`)
+		})
+	})
+}
@@ -61,6 +61,13 @@ const (
 	// Text2XL text of 2XL size
 	Text2XL TailwindClassName = "text-2xl"
 
+	// TextLeft text aligned to the left
+	TextLeft TailwindClassName = "text-left"
+	// TextCenter text aligned to the center
+	TextCenter TailwindClassName = "text-center"
+	// TextRight text aligned to the right
+	TextRight TailwindClassName = "text-right"
+
 	// TextBlue300 blue text light 300
 	TextBlue300 TailwindClassName = "text-blue-300"
 
@@ -168,3 +175,18 @@ func (basic HTMLElementView) Md(classNames ...TailwindClassName) HTMLElementView
 	// mutable.Class(classNameStrings...)
 	// return *mutable
 }
+
+// TailwindFor adds TailwindCSS class names to every Markdown element with the tag name
+func (md MarkdownView) TailwindFor(tagName string, additions ...TailwindClassName) MarkdownView {
+	return md.ClassesFor(tagName, TailwindToClass(additions...)...)
+}
+
+// ActiveTailwind adds TailwindCSS class names to the links to the current page and its ancestors
+func (menu NavMenuView) ActiveTailwind(additions ...TailwindClassName) NavMenuView {
+	return menu.ActiveClass(TailwindToClass(additions...)...)
+}
+
+// InactiveTailwind adds TailwindCSS class names to every other link
+func (menu NavMenuView) InactiveTailwind(additions ...TailwindClassName) NavMenuView {
+	return menu.InactiveClass(TailwindToClass(additions...)...)
+}
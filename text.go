@@ -0,0 +1,106 @@
+package dovetail
+
+import (
+	"time"
+
+	"golang.org/x/net/html/atom"
+)
+
+func Strong(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("strong", atom.Strong, children)
+}
+
+func Em(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("em", atom.Em, children)
+}
+
+func Code(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("code", atom.Code, children)
+}
+
+// Pre makes <pre>, whose whitespace is kept as-is
+func Pre(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("pre", atom.Pre, children)
+}
+
+// Kbd makes <kbd> for keyboard input, such as Kbd(Text("Ctrl"))
+func Kbd(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("kbd", atom.Kbd, children)
+}
+
+// Samp makes <samp> for output from a program
+func Samp(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("samp", atom.Samp, children)
+}
+
+// Abbr makes <abbr> with title being the expansion of the abbreviation
+func Abbr(title string, children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("abbr", atom.Abbr, append([]HTMLView{CustomAttr("title", title)}, children...))
+}
+
+// Time makes <time> with t as the machine-readable datetime attribute, and children as the human-readable text
+func Time(t time.Time, children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("time", atom.Time, append([]HTMLView{DateTime(t)}, children...))
+}
+
+// DateTime sets the datetime attribute of Time, Del or Ins
+func DateTime(t time.Time) HTMLAttrView {
+	return HTMLAttrView{Key: "datetime", Value: t.Format(time.RFC3339)}
+}
+
+// Mark makes <mark> for highlighted text, such as search matches
+func Mark(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("mark", atom.Mark, children)
+}
+
+// Small makes <small> for side comments such as fine print
+func Small(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("small", atom.Small, children)
+}
+
+func Sub(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("sub", atom.Sub, children)
+}
+
+func Sup(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("sup", atom.Sup, children)
+}
+
+func Blockquote(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("blockquote", atom.Blockquote, children)
+}
+
+// Q makes <q> for an inline quotation
+func Q(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("q", atom.Q, children)
+}
+
+// CiteURL sets the cite attribute of Blockquote, Q, Del or Ins, the URL of the source or explanation
+func CiteURL(url string) HTMLAttrView {
+	return HTMLAttrView{Key: "cite", Value: url}
+}
+
+// Br makes <br> for line breaks that are part of the content, such as in addresses or poems
+func Br() HTMLElementView {
+	return HTMLElementViewOf("br", atom.Br, nil)
+}
+
+// Hr makes <hr> for a change of topic between paragraphs
+func Hr() HTMLElementView {
+	return HTMLElementViewOf("hr", atom.Hr, nil)
+}
+
+// Del makes <del> for removed text, with DateTime and CiteURL enhancers
+func Del(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("del", atom.Del, children)
+}
+
+// Ins makes <ins> for inserted text, with DateTime and CiteURL enhancers
+func Ins(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("ins", atom.Ins, children)
+}
+
+// Data makes <data> with a machine-readable value, such as a product ID, and children as the human-readable text
+func Data(value string, children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("data", atom.Data, append([]HTMLView{CustomAttr("value", value)}, children...))
+}
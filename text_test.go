@@ -0,0 +1,52 @@
+package dovetail
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestTextLevelElements(t *testing.T) {
+	t.Run("Rendering inline text elements", func(t *testing.T) {
+		s := subjectAsString(P(
+			Strong(Text("Warning:")), Text(" "), Em(Text("really")), Text(" press "), Kbd(Text("Ctrl")), Text("+"), Kbd(Text("C")),
+			Text(" to copy "), Code(Text("x < y")), Text(", see "), Samp(Text("Copied")), Text(". "),
+			Abbr("HyperText Markup Language", Text("HTML")), Text(" "), Mark(Text("found")), Text(" "), Small(Text("fine print")),
+			Text(" H"), Sub(Text("2")), Text("O E=mc"), Sup(Text("2")),
+		))
+
+		t.Run(`it renders each element`, func(t *testing.T) {
+			assert.Equal(t, s, `<p><strong>Warning:</strong> <em>really</em> press <kbd>Ctrl</kbd>+<kbd>C</kbd> to copy <code>x &lt; y</code>, see <samp>Copied</samp>. `+
+				`<abbr title="HyperText Markup Language">HTML</abbr> <mark>found</mark> <small>fine print</small> H<sub>2</sub>O E=mc<sup>2</sup></p>`)
+		})
+	})
+
+	t.Run("Rendering Time, Del and Ins", func(t *testing.T) {
+		published := time.Date(2019, time.March, 14, 9, 30, 0, 0, time.FixedZone("AEDT", 11*60*60))
+		s := subjectAsString(P(
+			Time(published, Text("14 March")), Text(" "),
+			Del(Text("$20")).Use(DateTime(published.UTC())), Ins(Text("$15")).Use(DateTime(published), CiteURL("/changes/42")),
+		))
+
+		t.Run(`it renders the datetime attribute`, func(t *testing.T) {
+			assert.Equal(t, s, `<p><time datetime="2019-03-14T09:30:00+11:00">14 March</time> `+
+				`<del datetime="2019-03-13T22:30:00Z">$20</del><ins datetime="2019-03-14T09:30:00+11:00" cite="/changes/42">$15</ins></p>`)
+		})
+	})
+
+	t.Run("Rendering quotes, breaks and data", func(t *testing.T) {
+		s := subjectAsString(Div(
+			Blockquote(P(Text("Simple is better"))).Use(CiteURL("https://example.org/zen")),
+			P(Q(Text("Hello")).Use(CiteURL("javascript:alert(1)")), Br(), Data("SKU-42", Text("Walnut desk"))),
+			Pre(Text("  indented\n")),
+			Hr(),
+		))
+
+		t.Run(`it renders each element`, func(t *testing.T) {
+			assert.Equal(t, s, `<div><blockquote cite="https://example.org/zen"><p>Simple is better</p></blockquote>`+
+				`<p><q cite="about:invalid#zDovetailz">Hello</q><br/><data value="SKU-42">Walnut desk</data></p>`+
+				"<pre>  indented\n</pre><hr/></div>")
+		})
+	})
+}
@@ -0,0 +1,40 @@
+package dovetail
+
+import "golang.org/x/net/html/atom"
+
+// safeURLSchemes are the schemes allowed in URL attributes such as href and src. Relative URLs are always allowed.
+var safeURLSchemes = []string{"http", "https", "mailto", "tel"}
+
+// unsafeURL replaces URLs with schemes that are not allowed, such as javascript:, so they do nothing when followed
+const unsafeURL = "about:invalid#zDovetailz"
+
+// TrustedURL is a URL that is rendered as-is, even if its scheme such as data: or an app’s own scheme would not be allowed.
+// Never convert URLs from users to TrustedURL.
+type TrustedURL string
+
+// safeURL returns rawURL if it is relative or has a safe scheme, otherwise a URL that does nothing
+func safeURL(rawURL string) string {
+	if hasAllowedScheme(rawURL, safeURLSchemes) {
+		return rawURL
+	}
+	return unsafeURL
+}
+
+// TrustedURLAttr sets a URL attribute such as href or src without checking its scheme
+func TrustedURLAttr(key string, url TrustedURL) HTMLAttrView {
+	return HTMLAttrView{Key: key, Value: string(url), trusted: true}
+}
+
+// TrustedLink makes <a> like Link, but without checking the URL’s scheme
+func TrustedLink(url TrustedURL, children ...HTMLView) HTMLElementView {
+	children = append([]HTMLView{TrustedURLAttr("href", url)}, children...)
+
+	return HTMLElementViewOf("a", atom.A, children)
+}
+
+// TrustedImg makes <img> like Img, but without checking the URL’s scheme, such as for data: URLs
+func TrustedImg(srcURL TrustedURL, alt string, enhancers ...HTMLEnhancer) HTMLElementView {
+	view := HTMLElementViewOf("img", atom.Img, []HTMLView{TrustedURLAttr("src", srcURL), CustomAttr("alt", alt)})
+	view.elementCore = view.elementCore.Use(enhancers...)
+	return view
+}
@@ -0,0 +1,57 @@
+package dovetail
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestURLSafety(t *testing.T) {
+	t.Run("Rendering links with safe URLs", func(t *testing.T) {
+		s := subjectAsString(Div(
+			Link("/about", Text("About")),
+			Link("https://example.org?q=1", Text("Example")),
+			Link("MAILTO:hi@example.org", Text("Email")),
+			Link("tel:+61400000000", Text("Call")),
+			Link("#top", Text("Top")),
+		))
+
+		t.Run(`it renders the URLs as-is`, func(t *testing.T) {
+			assert.Equal(t, s, `<div><a href="/about">About</a><a href="https://example.org?q=1">Example</a><a href="MAILTO:hi@example.org">Email</a><a href="tel:+61400000000">Call</a><a href="#top">Top</a></div>`)
+		})
+	})
+
+	t.Run("Rendering unsafe URLs", func(t *testing.T) {
+		s := subjectAsString(Div(
+			Link(" java\tscript:alert(1)", Text("Link")),
+			Img("data:image/svg+xml,<svg onload=alert(1)>", "Image"),
+			HTMLElementViewOf("a", 0, []HTMLView{CustomAttr("HREF", "vbscript:x")}),
+			FormTo("javascript:alert(1)"),
+		))
+
+		t.Run(`it replaces them with a URL that does nothing`, func(t *testing.T) {
+			assert.Equal(t, s, `<div><a href="about:invalid#zDovetailz">Link</a><img src="about:invalid#zDovetailz" alt="Image"/><a HREF="about:invalid#zDovetailz"></a><form method="post" action="about:invalid#zDovetailz"></form></div>`)
+		})
+	})
+
+	t.Run("Rendering trusted URLs", func(t *testing.T) {
+		s := subjectAsString(Div(
+			TrustedLink("myapp:settings", Text("Settings")),
+			TrustedImg("data:image/png;base64,AAAA", "Dot"),
+			HTMLElementViewOf("video", 0, []HTMLView{TrustedURLAttr("poster", "data:image/png;base64,AAAA")}),
+			FormToTrusted("myapp:submit"),
+		))
+
+		t.Run(`it renders the URLs as-is`, func(t *testing.T) {
+			assert.Equal(t, s, `<div><a href="myapp:settings">Settings</a><img src="data:image/png;base64,AAAA" alt="Dot"/><video poster="data:image/png;base64,AAAA"></video><form method="post" action="myapp:submit"></form></div>`)
+		})
+	})
+
+	t.Run("Rendering Markdown with an unsafe link", func(t *testing.T) {
+		s := subjectAsString(Markdown("[click](javascript:alert(1))"))
+
+		t.Run(`it replaces the URL`, func(t *testing.T) {
+			assert.Equal(t, s, `<p><a href="about:invalid#zDovetailz">click</a></p>`)
+		})
+	})
+}
@@ -1,8 +1,10 @@
 package dovetail
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"golang.org/x/net/html"
@@ -11,7 +13,7 @@ import (
 
 // HTMLView applies changes to an html.Node, such as making it into an element or text node, or adding attributes
 type HTMLView interface {
-	apply(node *html.Node)
+	apply(node *html.Node, ctx *buildContext)
 }
 
 // HTMLEnhancer adds attributes but doesn’t add children
@@ -20,18 +22,83 @@ type HTMLEnhancer interface {
 	enhances() bool
 }
 
-// Build takes an HTMLView and creates an html.Node
-func Build(view HTMLView) *html.Node {
+// buildContext is shared by every view while building a tree, letting views contribute to the whole document
+type buildContext struct {
+	requestContext context.Context
+	head           headContent
+	errs           renderErrors
+	rendered       map[string]bool
+}
+
+func newBuildContext() *buildContext {
+	return &buildContext{requestContext: context.Background()}
+}
+
+// context returns the context passed to RenderContext, such as with the request’s CSP nonce
+func (ctx *buildContext) context() context.Context {
+	if ctx == nil || ctx.requestContext == nil {
+		return context.Background()
+	}
+	return ctx.requestContext
+}
+
+func (ctx *buildContext) build(view HTMLView) *html.Node {
 	node := &html.Node{}
-	view.apply(node)
+	view.apply(node, ctx)
 	return node
 }
 
-// Render takes an HTMLView and renders it and its tree to w
-func Render(w io.Writer, views ...HTMLView) {
+// firstTime reports whether key is being rendered for the first time, for content such as SVG symbols that must only be rendered once
+func (ctx *buildContext) firstTime(key string) bool {
+	if ctx.rendered == nil {
+		ctx.rendered = make(map[string]bool)
+	}
+	if ctx.rendered[key] {
+		return false
+	}
+	ctx.rendered[key] = true
+	return true
+}
+
+// report records an error, which is returned once rendering has finished
+func (ctx *buildContext) report(err error) {
+	if ctx != nil {
+		ctx.errs = append(ctx.errs, err)
+	}
+}
+
+// err returns the errors reported while building, if any
+func (ctx *buildContext) err() error {
+	switch len(ctx.errs) {
+	case 0:
+		return nil
+	case 1:
+		return ctx.errs[0]
+	}
+	return ctx.errs
+}
+
+// Build takes an HTMLView and creates an html.Node
+func Build(view HTMLView) *html.Node {
+	return newBuildContext().build(view)
+}
+
+// Render takes an HTMLView and renders it and its tree to w.
+// Invalid attributes are left out, and returned as an error after rendering everything else.
+func Render(w io.Writer, views ...HTMLView) error {
+	return RenderContext(context.Background(), w, views...)
+}
+
+// RenderContext renders like Render, with values from ctx such as the nonce set by ContentSecurityPolicy.Middleware
+func RenderContext(ctx context.Context, w io.Writer, views ...HTMLView) error {
+	buildCtx := newBuildContext()
+	buildCtx.requestContext = ctx
 	for _, view := range views {
-		html.Render(w, Build(view))
+		if err := html.Render(w, buildCtx.build(view)); err != nil {
+			return err
+		}
 	}
+	return buildCtx.err()
 }
 
 // HTMLText represents an HTML text node
@@ -44,7 +111,7 @@ func Text(text string) HTMLText {
 	return HTMLText{text}
 }
 
-func (text HTMLText) apply(node *html.Node) {
+func (text HTMLText) apply(node *html.Node, ctx *buildContext) {
 	node.Type = html.TextNode
 	node.Data = text.Text
 }
@@ -82,22 +149,46 @@ func (core HTMLElementCore) Use(enhancers ...HTMLEnhancer) HTMLElementCore {
 	return core
 }
 
-func (core HTMLElementCore) applyToNode(node *html.Node) {
+// flattenViews replaces each combined view with the views it combines
+func flattenViews(views []HTMLView) []HTMLView {
+	flattened := views
+	for i, view := range views {
+		if combined, ok := view.(combinedView); ok {
+			flattened = append(views[:i:i], flattenViews(combined.views)...)
+			for _, rest := range views[i+1:] {
+				flattened = append(flattened, flattenViews([]HTMLView{rest})...)
+			}
+			break
+		}
+	}
+	return flattened
+}
+
+func (core HTMLElementCore) applyToNode(node *html.Node, ctx *buildContext) {
 	classNames := core.classNames
 
-	for _, child := range core.children {
+	for _, child := range flattenViews(core.children) {
 		switch child := child.(type) {
 		case HTMLAttrView:
-			child.apply(node)
+			child.apply(node, ctx)
 		case HTMLClassNameView:
 			classNames = classNames.Concat(child.classNames)
+		case headView:
+			child.collect(ctx)
+		case HTMLRawView:
+			if core.childTransformer != nil {
+				node.AppendChild(core.childTransformer(ctx.build(child)))
+			} else {
+				// Parse in the context of this element, so fragments like <tr> are valid within <table>
+				child.appendTo(node)
+			}
 		case HTMLView:
-			childNode := &html.Node{}
-			child.apply(childNode)
+			childNode := ctx.build(child)
 			if core.childTransformer != nil {
-				childNode = core.childTransformer(childNode)
+				node.AppendChild(core.childTransformer(childNode))
+			} else {
+				appendNode(node, childNode)
 			}
-			node.AppendChild(childNode)
 		}
 	}
 
@@ -106,6 +197,21 @@ func (core HTMLElementCore) applyToNode(node *html.Node) {
 	}
 }
 
+// appendNode appends child to parent, or if child is a fragment, splices in its children
+func appendNode(parent *html.Node, child *html.Node) {
+	if child.Type != html.DocumentNode {
+		parent.AppendChild(child)
+		return
+	}
+
+	for grandchild := child.FirstChild; grandchild != nil; {
+		next := grandchild.NextSibling
+		child.RemoveChild(grandchild)
+		parent.AppendChild(grandchild)
+		grandchild = next
+	}
+}
+
 // Heading lets you render h1, h2, h3, etc
 type Heading struct {
 	level       int
@@ -117,7 +223,7 @@ func H(level int, children ...HTMLView) HTMLView {
 	return Heading{level: level, elementCore: HTMLElementCore{children: children}}
 }
 
-func (h Heading) apply(node *html.Node) {
+func (h Heading) apply(node *html.Node, ctx *buildContext) {
 	node.Type = html.ElementNode
 	switch h.level {
 	case 1:
@@ -142,7 +248,7 @@ func (h Heading) apply(node *html.Node) {
 		panic(fmt.Sprintf("Unsupported heading level %v", h.level))
 	}
 
-	h.elementCore.applyToNode(node)
+	h.elementCore.applyToNode(node, ctx)
 }
 
 // ButtonView makes <button>
@@ -175,7 +281,7 @@ func (button ButtonView) Submit() ButtonView {
 	return button
 }
 
-func (button ButtonView) apply(node *html.Node) {
+func (button ButtonView) apply(node *html.Node, ctx *buildContext) {
 	buttonType := button.buttonType
 	if buttonType == "" {
 		buttonType = "button"
@@ -186,7 +292,7 @@ func (button ButtonView) apply(node *html.Node) {
 	node.DataAtom = atom.Button
 	node.Attr = []html.Attribute{{Key: "type", Val: buttonType}}
 
-	button.elementCore.applyToNode(node)
+	button.elementCore.applyToNode(node, ctx)
 }
 
 //
@@ -195,6 +301,7 @@ func (button ButtonView) apply(node *html.Node) {
 type HTMLElementView struct {
 	tagName     string
 	tagAtom     atom.Atom
+	namespace   string
 	elementCore HTMLElementCore
 }
 
@@ -225,12 +332,13 @@ func (el HTMLElementView) ChangeClasses(changer func(classNames ClassNames) Clas
 	return el
 }
 
-func (el HTMLElementView) apply(node *html.Node) {
+func (el HTMLElementView) apply(node *html.Node, ctx *buildContext) {
 	node.Type = html.ElementNode
 	node.Data = el.tagName
 	node.DataAtom = el.tagAtom
+	node.Namespace = el.namespace
 
-	el.elementCore.applyToNode(node)
+	el.elementCore.applyToNode(node, ctx)
 }
 
 func HTMLElementViewOf(tagName string, tagAtom atom.Atom, children []HTMLView) HTMLElementView {
@@ -290,25 +398,92 @@ func Li(children ...HTMLView) HTMLElementView {
 	return HTMLElementViewOf("li", atom.Li, children)
 }
 
+// wrapInLi is the childTransformer for List and OrderedList
+func wrapInLi(node *html.Node) *html.Node {
+	li := &html.Node{
+		Type:     html.ElementNode,
+		Data:     "li",
+		DataAtom: atom.Li,
+	}
+	appendNode(li, node)
+	return li
+}
+
 func List(children ...HTMLView) HTMLElementView {
 	return HTMLElementView{
 		tagName: "ul",
 		tagAtom: atom.Ul,
 		elementCore: HTMLElementCore{
-			children: children,
-			childTransformer: func(node *html.Node) *html.Node {
-				li := &html.Node{
-					Type:     html.ElementNode,
-					Data:     "li",
-					DataAtom: atom.Li,
-				}
-				li.AppendChild(node)
-				return li
-			},
+			children:         children,
+			childTransformer: wrapInLi,
 		},
 	}
 }
 
+func Ol(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("ol", atom.Ol, children)
+}
+
+// OrderedList makes <ol>, wrapping each child in <li> like List
+func OrderedList(children ...HTMLView) HTMLElementView {
+	return HTMLElementView{
+		tagName: "ol",
+		tagAtom: atom.Ol,
+		elementCore: HTMLElementCore{
+			children:         children,
+			childTransformer: wrapInLi,
+		},
+	}
+}
+
+// ListStart sets the number of the first item of <ol>
+func ListStart(number int) HTMLAttrView {
+	return HTMLAttrView{Key: "start", Value: strconv.Itoa(number)}
+}
+
+// ListReversed numbers the items of <ol> from highest to lowest
+var ListReversed = HTMLAttrView{Key: "reversed"}
+
+// ListType sets the numbering of <ol>, one of "1", "a", "A", "i" or "I"
+func ListType(numbering string) HTMLAttrView {
+	return HTMLAttrView{Key: "type", Value: numbering}
+}
+
+// DescriptionGroup is a term and its descriptions within DescriptionList
+type DescriptionGroup struct {
+	term         HTMLView
+	descriptions []HTMLView
+}
+
+// Term makes a group for DescriptionList, with the term and one or more descriptions
+func Term(term HTMLView, descriptions ...HTMLView) DescriptionGroup {
+	return DescriptionGroup{term: term, descriptions: descriptions}
+}
+
+// DescriptionList makes <dl> with a <dt> for each group’s term followed by a <dd> for each of its descriptions
+func DescriptionList(groups ...DescriptionGroup) HTMLElementView {
+	children := make([]HTMLView, 0, len(groups)*2)
+	for _, group := range groups {
+		children = append(children, Dt(group.term))
+		for _, description := range group.descriptions {
+			children = append(children, Dd(description))
+		}
+	}
+	return Dl(children...)
+}
+
+func Dl(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("dl", atom.Dl, children)
+}
+
+func Dt(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("dt", atom.Dt, children)
+}
+
+func Dd(children ...HTMLView) HTMLElementView {
+	return HTMLElementViewOf("dd", atom.Dd, children)
+}
+
 func Nav(children ...HTMLView) HTMLElementView {
 	return HTMLElementViewOf("nav", atom.Nav, children)
 }
@@ -367,9 +542,9 @@ type combinedView struct {
 	views []HTMLView
 }
 
-func (combined combinedView) apply(node *html.Node) {
+func (combined combinedView) apply(node *html.Node, ctx *buildContext) {
 	for _, view := range combined.views {
-		view.apply(node)
+		view.apply(node, ctx)
 	}
 }
 
@@ -379,14 +554,27 @@ func Combine(views ...HTMLView) HTMLView {
 	return combinedView{views: views}
 }
 
-// HTMLAttrView allows setting HTML attributes
+// HTMLAttrView allows setting HTML attributes.
+// URL attributes such as href and src must be relative or use http, https, mailto or tel, otherwise they are replaced with a URL that does nothing.
+// Keys that are malformed, event handlers, or unknown aria-* or invalid data-* attributes are left out and reported by Render.
 type HTMLAttrView struct {
-	Key   string
-	Value string
+	Key          string
+	Value        string
+	trusted      bool
+	eventHandler bool
 }
 
-func (attrView HTMLAttrView) apply(node *html.Node) {
-	node.Attr = append(node.Attr, html.Attribute{Key: attrView.Key, Val: attrView.Value})
+func (attrView HTMLAttrView) apply(node *html.Node, ctx *buildContext) {
+	if err := validateAttrKey(attrView.Key, attrView.eventHandler); err != nil {
+		ctx.report(err)
+		return
+	}
+
+	value := attrView.Value
+	if !attrView.trusted && isURLAttr(strings.ToLower(attrView.Key)) {
+		value = safeURL(value)
+	}
+	node.Attr = append(node.Attr, html.Attribute{Key: attrView.Key, Val: value})
 }
 
 func (HTMLAttrView) enhances() bool { return true }
@@ -419,6 +607,12 @@ func DataAttr(key string, value string) HTMLAttrView {
 	return HTMLAttrView{Key: "data-" + key, Value: value}
 }
 
+// EventHandlerAttr sets an inline event handler such as onclick, when passed "click".
+// Event handler attributes are only allowed this way, so keys from data can never add scripts.
+func EventHandlerAttr(event string, script string) HTMLAttrView {
+	return HTMLAttrView{Key: "on" + event, Value: script, eventHandler: true}
+}
+
 // CustomAttr is for data attributes such as href or src
 func CustomAttr(key string, value string) HTMLAttrView {
 	return HTMLAttrView{Key: key, Value: value}
@@ -430,7 +624,7 @@ type HTMLClassNameView struct {
 }
 
 // This method is not actually used, instead the class names are all merged before setting the class attribute
-func (view HTMLClassNameView) apply(node *html.Node) {
+func (view HTMLClassNameView) apply(node *html.Node, ctx *buildContext) {
 	node.Attr = append(node.Attr, html.Attribute{Key: "class", Val: view.classNames.String()})
 }
 
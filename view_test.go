@@ -93,6 +93,35 @@ func TestRender(t *testing.T) {
 		})
 	})
 
+	t.Run("Rendering OrderedList with options", func(t *testing.T) {
+		s := subjectAsString(
+			OrderedList(
+				ListStart(3),
+				ListReversed,
+				ListType("i"),
+				Text("Third"),
+				Link("/second", Text("Second")),
+			),
+		)
+
+		t.Run(`it renders <ol> with attributes and 2 <li>`, func(t *testing.T) {
+			assert.Equal(t, s, `<ol start="3" reversed="" type="i"><li>Third</li><li><a href="/second">Second</a></li></ol>`)
+		})
+	})
+
+	t.Run("Rendering DescriptionList with multiple descriptions per term", func(t *testing.T) {
+		s := subjectAsString(
+			DescriptionList(
+				Term(Text("Firefox"), Text("A web browser"), Text("A red panda")),
+				Term(Text("Go"), Text("A programming language")),
+			),
+		)
+
+		t.Run(`it renders <dl> with a <dt> for each term followed by its <dd>`, func(t *testing.T) {
+			assert.Equal(t, s, `<dl><dt>Firefox</dt><dd>A web browser</dd><dd>A red panda</dd><dt>Go</dt><dd>A programming language</dd></dl>`)
+		})
+	})
+
 	t.Run("Rendering H1", func(t *testing.T) {
 		s := subjectAsString(H(1, Text("Hello")))
 
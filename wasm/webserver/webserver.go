@@ -1,9 +1,25 @@
 package main
 
 import (
+	"log"
 	"net/http"
+	"os"
+
+	"github.com/RoyalIcing/dovetail"
 )
 
+// buildDir is made by `make run_wasm_http`, which builds main.wasm and copies index.html and wasm_exec.js into it
+const buildDir = `./build`
+
 func main() {
-	http.ListenAndServe(`:8080`, http.FileServer(http.Dir(`./build`)))
+	if _, err := os.Stat(buildDir); os.IsNotExist(err) {
+		log.Fatalf("webserver: %s does not exist, run `make run_wasm_http` from the repository root to build it", buildDir)
+	}
+
+	assets, err := dovetail.LoadAssets(http.Dir(buildDir), `/`)
+	if err != nil {
+		log.Fatalf("webserver: loading %s: %v", buildDir, err)
+	}
+
+	log.Fatal(http.ListenAndServe(`:8080`, assets))
 }